@@ -0,0 +1,105 @@
+package goredis
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Overflow behaviors of BitFieldCommand.Overflow.
+const (
+	OverflowWrap = "WRAP"
+	OverflowSat  = "SAT"
+	OverflowFail = "FAIL"
+)
+
+// BitFieldSigned returns the type of a signed integer of bits width, up to 64.
+func BitFieldSigned(bits int) string {
+	return "i" + strconv.Itoa(bits)
+}
+
+// BitFieldUnsigned returns the type of an unsigned integer of bits width, up to 63.
+func BitFieldUnsigned(bits int) string {
+	return "u" + strconv.Itoa(bits)
+}
+
+// BitFieldCommand represents a redis BITFIELD command.
+// Offsets are bit offsets, or when prefixed with # multiplied by the type width,
+// so #2 is the third integer of an array of integers of the same type.
+type BitFieldCommand struct {
+	redis    *Redis
+	key      string
+	readOnly bool
+	args     []interface{}
+}
+
+// BitField doc: http://redis.io/commands/bitfield
+// BITFIELD key [GET type offset] [SET type offset value] [INCRBY type offset increment] [OVERFLOW WRAP|SAT|FAIL]
+func (r *Redis) BitField(key string) *BitFieldCommand {
+	return &BitFieldCommand{redis: r, key: key}
+}
+
+// BitFieldRO is the read-only variant of BitField, only Get is allowed.
+// Available since Redis 6.0.
+func (r *Redis) BitFieldRO(key string) *BitFieldCommand {
+	return &BitFieldCommand{redis: r, key: key, readOnly: true}
+}
+
+// Get returns the integer of type at offset.
+func (b *BitFieldCommand) Get(typ, offset string) *BitFieldCommand {
+	b.args = append(b.args, "GET", typ, offset)
+	return b
+}
+
+// Set sets the integer of type at offset to value, and returns its old value.
+func (b *BitFieldCommand) Set(typ, offset string, value int64) *BitFieldCommand {
+	b.args = append(b.args, "SET", typ, offset, value)
+	return b
+}
+
+// IncrBy increments the integer of type at offset, and returns its new value.
+func (b *BitFieldCommand) IncrBy(typ, offset string, increment int64) *BitFieldCommand {
+	b.args = append(b.args, "INCRBY", typ, offset, increment)
+	return b
+}
+
+// Overflow sets the overflow behavior of the following Set and IncrBy, WRAP by default.
+func (b *BitFieldCommand) Overflow(behavior string) *BitFieldCommand {
+	b.args = append(b.args, "OVERFLOW", behavior)
+	return b
+}
+
+// Run performs redis bitfield command.
+// It returns the result of every Get, Set and IncrBy in order,
+// nil for the operations not performed because of OVERFLOW FAIL.
+func (b *BitFieldCommand) Run() ([]*int64, error) {
+	name := "BITFIELD"
+	if b.readOnly {
+		name = "BITFIELD_RO"
+		for i := 0; i < len(b.args); i += 3 {
+			if b.args[i] != "GET" {
+				return nil, errors.New("bitfield_ro only supports GET")
+			}
+		}
+	}
+	args := packArgs(name, b.key, b.args)
+	rp, err := b.redis.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*int64, len(multi))
+	for i, subrp := range multi {
+		if subrp.Type == BulkReply && subrp.Bulk == nil {
+			continue
+		}
+		n, err := subrp.IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &n
+	}
+	return results, nil
+}
@@ -0,0 +1,35 @@
+package goredis
+
+import (
+	"testing"
+)
+
+func TestBitField(t *testing.T) {
+	r.Del("key")
+	results, err := r.BitField("key").
+		Set(BitFieldUnsigned(8), "#0", 200).
+		IncrBy(BitFieldUnsigned(8), "#0", 100).
+		Overflow(OverflowSat).IncrBy(BitFieldUnsigned(8), "#1", 300).
+		Overflow(OverflowFail).IncrBy(BitFieldSigned(8), "#2", 200).
+		Get(BitFieldUnsigned(8), "0").
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 || *results[0] != 0 || *results[1] != 44 || *results[2] != 255 || results[3] != nil || *results[4] != 44 {
+		t.Fail()
+	}
+}
+
+func TestBitFieldRO(t *testing.T) {
+	r.Del("key")
+	r.BitField("key").Set(BitFieldSigned(16), "#1", -2).Run()
+	if results, err := r.BitFieldRO("key").Get(BitFieldSigned(16), "16").Run(); err != nil {
+		t.Error(err)
+	} else if len(results) != 1 || *results[0] != -2 {
+		t.Fail()
+	}
+	if _, err := r.BitFieldRO("key").Set(BitFieldSigned(16), "0", 1).Run(); err == nil {
+		t.Fail()
+	}
+}
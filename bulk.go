@@ -0,0 +1,152 @@
+package goredis
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultBulkBatchSize is the batch size of the bulk operations when BulkArgs.BatchSize is 0.
+const DefaultBulkBatchSize = 100
+
+// BulkArgs is the options of DelMatching, ExpireMatching and RenameMatching.
+//
+// The keys are scanned BatchSize at a time (the COUNT hint of SCAN),
+// and the commands of a batch are sent in one pipeline.
+// Interval is the pause between two batches, to limit the load on the server.
+// Unlink deletes with UNLINK instead of DEL, reclaiming the memory in the background (Redis 4.0).
+// DryRun only counts the keys which would be changed, without changing them,
+// a key returned twice by SCAN is counted twice then.
+type BulkArgs struct {
+	BatchSize int
+	Interval  time.Duration
+	Unlink    bool
+	DryRun    bool
+}
+
+// DelMatching deletes the keys matching pattern, without blocking the server like KEYS would.
+// It returns the number of keys deleted. args may be nil.
+func (r *Redis) DelMatching(pattern string, args *BulkArgs) (int64, error) {
+	command := "DEL"
+	if args != nil && args.Unlink {
+		command = "UNLINK"
+	}
+	return r.bulkMatching(pattern, args, func(key string) []interface{} {
+		return []interface{}{command, key}
+	}, integerCount)
+}
+
+// ExpireMatching sets a timeout of ttl, at millisecond resolution, on the keys matching pattern.
+// ttl must be at least a millisecond, since Redis deletes a key given a timeout of 0,
+// DelMatching is the way to delete the keys.
+// It returns the number of keys whose timeout was set. args may be nil.
+func (r *Redis) ExpireMatching(pattern string, ttl time.Duration, args *BulkArgs) (int64, error) {
+	if ttl < time.Millisecond {
+		return 0, errors.New("expire matching: ttl under a millisecond")
+	}
+	ms := int64(ttl / time.Millisecond)
+	return r.bulkMatching(pattern, args, func(key string) []interface{} {
+		return []interface{}{"PEXPIRE", key, ms}
+	}, integerCount)
+}
+
+// RenameMatching renames the keys matching pattern to rename(key),
+// the keys for which rename returns an empty string or the key itself are left alone.
+// An existing destination key is overwritten, like RENAME does.
+// The new names should not match pattern, or a key may be scanned and renamed again.
+// It returns the number of keys renamed. args may be nil.
+func (r *Redis) RenameMatching(pattern string, rename func(key string) string, args *BulkArgs) (int64, error) {
+	return r.bulkMatching(pattern, args, func(key string) []interface{} {
+		newkey := rename(key)
+		if newkey == "" || newkey == key {
+			return nil
+		}
+		return []interface{}{"RENAME", key, newkey}
+	}, func(rp *Reply) (int64, error) {
+		if err := rp.OKValue(); err != nil {
+			// The key was deleted since it was scanned.
+			if strings.Contains(err.Error(), "no such key") {
+				return 0, nil
+			}
+			return 0, err
+		}
+		return 1, nil
+	})
+}
+
+// bulkMatching scans the keys matching pattern and pipelines the commands of send by batch,
+// summing the count of every reply.
+// send returns the command for a key, or nil to leave the key alone,
+// which DryRun does not count then, like a real run would not.
+func (r *Redis) bulkMatching(pattern string, args *BulkArgs, send func(key string) []interface{}, count func(*Reply) (int64, error)) (int64, error) {
+	if args == nil {
+		args = &BulkArgs{}
+	}
+	size := args.BatchSize
+	if size <= 0 {
+		size = DefaultBulkBatchSize
+	}
+	var total int64
+	process := func(keys []string) error {
+		if args.DryRun {
+			for _, key := range keys {
+				if send(key) != nil {
+					total++
+				}
+			}
+			return nil
+		}
+		p, err := r.Pipelining()
+		if err != nil {
+			return err
+		}
+		defer p.Close()
+		for _, key := range keys {
+			cmd := send(key)
+			if cmd == nil {
+				continue
+			}
+			if err := p.Command(cmd...); err != nil {
+				return err
+			}
+		}
+		rps, err := p.ReceiveAll()
+		if err != nil {
+			return err
+		}
+		for _, rp := range rps {
+			n, err := count(rp)
+			if err != nil {
+				return err
+			}
+			total += n
+		}
+		return nil
+	}
+	it := r.ScanIterator(pattern, size, "")
+	keys := make([]string, 0, size)
+	batches := 0
+	for {
+		more := it.Next()
+		if more {
+			keys = append(keys, it.Val())
+		}
+		if len(keys) == size || (!more && len(keys) > 0) {
+			if batches > 0 && args.Interval > 0 {
+				time.Sleep(args.Interval)
+			}
+			if err := process(keys); err != nil {
+				return total, err
+			}
+			batches++
+			keys = keys[:0]
+		}
+		if !more {
+			return total, it.Err()
+		}
+	}
+}
+
+func integerCount(rp *Reply) (int64, error) {
+	return rp.IntegerValue()
+}
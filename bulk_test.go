@@ -0,0 +1,99 @@
+package goredis
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setTenantKeys writes n keys bulk:tenant:42:i and bulk:tenant:7:0,
+// and returns the keys to delete at the end of the test.
+func setTenantKeys(t *testing.T, n int) []string {
+	keys := []string{"bulk:tenant:7:0"}
+	for i := 0; i < n; i++ {
+		keys = append(keys, "bulk:tenant:42:"+strconv.Itoa(i))
+	}
+	r.Del(keys...)
+	for _, key := range keys {
+		if err := r.Set(key, "value", 0, 0, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keys
+}
+
+func TestDelMatching(t *testing.T) {
+	keys := setTenantKeys(t, 25)
+	defer r.Del(keys...)
+	n, err := r.DelMatching("bulk:tenant:42:*", &BulkArgs{BatchSize: 10, DryRun: true})
+	if err != nil || n != 25 {
+		t.Fatal(n, err)
+	}
+	if exists, _ := r.Exists("bulk:tenant:42:24"); !exists {
+		t.Fatal("dry run deleted keys")
+	}
+	n, err = r.DelMatching("bulk:tenant:42:*", &BulkArgs{BatchSize: 10, Interval: time.Millisecond})
+	if err != nil || n != 25 {
+		t.Fatal(n, err)
+	}
+	if remaining, _ := r.Keys("bulk:tenant:42:*"); len(remaining) != 0 {
+		t.Error(remaining)
+	}
+	if exists, _ := r.Exists("bulk:tenant:7:0"); !exists {
+		t.Error("unmatched key deleted")
+	}
+}
+
+func TestExpireMatching(t *testing.T) {
+	keys := setTenantKeys(t, 5)
+	defer r.Del(keys...)
+	for _, ttl := range []time.Duration{0, time.Microsecond} {
+		if _, err := r.ExpireMatching("bulk:tenant:42:*", ttl, nil); err == nil {
+			t.Error("expire with a ttl of", ttl)
+		}
+	}
+	if exists, _ := r.Exists("bulk:tenant:42:0"); !exists {
+		t.Fatal("key deleted by a ttl under a millisecond")
+	}
+	n, err := r.ExpireMatching("bulk:tenant:42:*", time.Minute, nil)
+	if err != nil || n != 5 {
+		t.Fatal(n, err)
+	}
+	if ttl, _ := r.TTL("bulk:tenant:42:3"); ttl <= 0 || ttl > 60 {
+		t.Error(ttl)
+	}
+	if ttl, _ := r.TTL("bulk:tenant:7:0"); ttl != -1 {
+		t.Error(ttl)
+	}
+}
+
+func TestRenameMatching(t *testing.T) {
+	keys := setTenantKeys(t, 5)
+	archived := []string{"bulk:archive:42:1", "bulk:archive:42:2", "bulk:archive:42:3", "bulk:archive:42:4"}
+	r.Del(archived...)
+	defer r.Del(append(keys, archived...)...)
+	rename := func(key string) string {
+		if key == "bulk:tenant:42:0" {
+			return ""
+		}
+		return strings.Replace(key, "bulk:tenant:42:", "bulk:archive:42:", 1)
+	}
+	n, err := r.RenameMatching("bulk:tenant:42:*", rename, &BulkArgs{BatchSize: 2, DryRun: true})
+	if err != nil || n != 4 {
+		t.Fatal("dry run", n, err)
+	}
+	if exists, _ := r.Exists("bulk:archive:42:3"); exists {
+		t.Fatal("dry run renamed keys")
+	}
+	n, err = r.RenameMatching("bulk:tenant:42:*", rename, &BulkArgs{BatchSize: 2})
+	if err != nil || n != 4 {
+		t.Fatal(n, err)
+	}
+	if value, _ := r.Get("bulk:archive:42:3"); string(value) != "value" {
+		t.Error(string(value))
+	}
+	if exists, _ := r.Exists("bulk:tenant:42:0"); !exists {
+		t.Error("skipped key renamed")
+	}
+}
@@ -0,0 +1,418 @@
+package goredis
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ClusterSlots is the number of hash slots of a redis cluster.
+const ClusterSlots = 16384
+
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// KeySlot returns the hash slot of key, computed locally like CLUSTER KEYSLOT does.
+// Only the part inside the first non-empty {...} is hashed if there is one.
+func KeySlot(key string) int {
+	return int(crc16([]byte(hashTag(key))) % ClusterSlots)
+}
+
+// SlotRange is a range of hash slots, both Start and End are inclusive.
+type SlotRange struct {
+	Start int
+	End   int
+}
+
+// ClusterNode is a node of the cluster as described by CLUSTER NODES.
+// Master is the ID of the master for a replica, empty for a master.
+// Migrating and Importing map a slot to the node it is moved to or from.
+type ClusterNode struct {
+	ID          string
+	Address     string
+	BusPort     int
+	Hostname    string
+	Flags       []string
+	Master      string
+	PingSent    int64
+	PongRecv    int64
+	ConfigEpoch int64
+	LinkState   string
+	Slots       []SlotRange
+	Migrating   map[int]string
+	Importing   map[int]string
+}
+
+// HasFlag returns true if flag(myself, master, slave, fail?, fail, handshake, noaddr, nofailover) is set.
+func (n *ClusterNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterShardNode is a node of a shard as described by CLUSTER SHARDS.
+// Port or TLSPort is 0 when the node does not listen on it.
+type ClusterShardNode struct {
+	ID                string
+	Endpoint          string
+	IP                string
+	Hostname          string
+	Port              int64
+	TLSPort           int64
+	Role              string
+	ReplicationOffset int64
+	Health            string
+}
+
+// ClusterShard is a master and its replicas with the slots they serve.
+type ClusterShard struct {
+	Slots []SlotRange
+	Nodes []*ClusterShardNode
+}
+
+// ClusterInfo provides INFO style information about Redis Cluster vital parameters.
+func (r *Redis) ClusterInfo() (map[string]string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "INFO")
+	if err != nil {
+		return nil, err
+	}
+	info, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	return infoFields(info), nil
+}
+
+// ClusterMyID returns the node's id.
+func (r *Redis) ClusterMyID() (string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "MYID")
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// ClusterNodes returns the cluster configuration as seen by the node the client is connected to.
+func (r *Redis) ClusterNodes() ([]*ClusterNode, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "NODES")
+	if err != nil {
+		return nil, err
+	}
+	s, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	return parseClusterNodes(s)
+}
+
+// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+func parseClusterNodes(s string) ([]*ClusterNode, error) {
+	var nodes []*ClusterNode
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 8 {
+			return nil, errors.New("cluster nodes protocol error")
+		}
+		node := &ClusterNode{
+			ID:        fields[0],
+			Flags:     strings.Split(fields[2], ","),
+			LinkState: fields[7],
+		}
+		addr := fields[1]
+		if i := strings.IndexByte(addr, ','); i >= 0 {
+			node.Hostname = addr[i+1:]
+			addr = addr[:i]
+		}
+		if i := strings.IndexByte(addr, '@'); i >= 0 {
+			port, err := strconv.Atoi(addr[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			node.BusPort = port
+			addr = addr[:i]
+		}
+		node.Address = addr
+		if fields[3] != "-" {
+			node.Master = fields[3]
+		}
+		var err error
+		if node.PingSent, err = strconv.ParseInt(fields[4], 10, 64); err != nil {
+			return nil, err
+		}
+		if node.PongRecv, err = strconv.ParseInt(fields[5], 10, 64); err != nil {
+			return nil, err
+		}
+		if node.ConfigEpoch, err = strconv.ParseInt(fields[6], 10, 64); err != nil {
+			return nil, err
+		}
+		for _, slot := range fields[8:] {
+			if err := node.addSlot(slot); err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// addSlot parses a slot of CLUSTER NODES: 42, 0-5460, [42->-<id>] or [42-<-<id>].
+func (n *ClusterNode) addSlot(slot string) error {
+	if strings.HasPrefix(slot, "[") && strings.HasSuffix(slot, "]") {
+		slot = slot[1 : len(slot)-1]
+		if i := strings.Index(slot, "->-"); i > 0 {
+			number, err := strconv.Atoi(slot[:i])
+			if err != nil {
+				return err
+			}
+			if n.Migrating == nil {
+				n.Migrating = make(map[int]string)
+			}
+			n.Migrating[number] = slot[i+3:]
+			return nil
+		}
+		if i := strings.Index(slot, "-<-"); i > 0 {
+			number, err := strconv.Atoi(slot[:i])
+			if err != nil {
+				return err
+			}
+			if n.Importing == nil {
+				n.Importing = make(map[int]string)
+			}
+			n.Importing[number] = slot[i+3:]
+			return nil
+		}
+		return errors.New("cluster nodes protocol error")
+	}
+	start, end := slot, slot
+	if i := strings.IndexByte(slot, '-'); i > 0 {
+		start, end = slot[:i], slot[i+1:]
+	}
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return err
+	}
+	e, err := strconv.Atoi(end)
+	if err != nil {
+		return err
+	}
+	n.Slots = append(n.Slots, SlotRange{s, e})
+	return nil
+}
+
+// ClusterShards returns details about the shards of the cluster.
+// Available since Redis 7.0.
+func (r *Redis) ClusterShards() ([]*ClusterShard, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "SHARDS")
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	shards := make([]*ClusterShard, 0, len(multi))
+	for _, subrp := range multi {
+		slots := subrp.field("slots")
+		nodes := subrp.field("nodes")
+		if slots.Type != MultiReply || nodes.Type != MultiReply || len(slots.Multi)%2 != 0 {
+			return nil, errors.New("cluster shards protocol error")
+		}
+		shard := &ClusterShard{}
+		for i := 0; i < len(slots.Multi); i += 2 {
+			start, err := slots.Multi[i].IntegerValue()
+			if err != nil {
+				return nil, err
+			}
+			end, err := slots.Multi[i+1].IntegerValue()
+			if err != nil {
+				return nil, err
+			}
+			shard.Slots = append(shard.Slots, SlotRange{int(start), int(end)})
+		}
+		for _, node := range nodes.Multi {
+			n := &ClusterShardNode{}
+			n.ID, _ = node.field("id").StringValue()
+			n.Endpoint, _ = node.field("endpoint").StringValue()
+			n.IP, _ = node.field("ip").StringValue()
+			n.Hostname, _ = node.field("hostname").StringValue()
+			n.Port, _ = node.field("port").IntegerValue()
+			n.TLSPort, _ = node.field("tls-port").IntegerValue()
+			n.Role, _ = node.field("role").StringValue()
+			n.ReplicationOffset, _ = node.field("replication-offset").IntegerValue()
+			n.Health, _ = node.field("health").StringValue()
+			shard.Nodes = append(shard.Nodes, n)
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
+// ClusterShardReplicas returns the addresses of the online replicas
+// in the cluster shard the connected node belongs to.
+// It can be used as ReplicaConfig.Replicas together with ReplicaConfig.ReadOnly.
+func (r *Redis) ClusterShardReplicas() ([]string, error) {
+	id, err := r.ClusterMyID()
+	if err != nil {
+		return nil, err
+	}
+	shards, err := r.ClusterShards()
+	if err != nil {
+		return nil, err
+	}
+	for _, shard := range shards {
+		var mine bool
+		var addresses []string
+		for _, node := range shard.Nodes {
+			if node.ID == id {
+				mine = true
+			} else if node.Role == "replica" && node.Health == "online" {
+				addresses = append(addresses, net.JoinHostPort(node.IP, strconv.FormatInt(node.Port, 10)))
+			}
+		}
+		if mine {
+			return addresses, nil
+		}
+	}
+	return nil, errors.New("cluster shards: node " + id + " not found")
+}
+
+// ClusterAddSlots assigns the slots to the node the client is connected to.
+func (r *Redis) ClusterAddSlots(slots ...int) error {
+	args := packArgs("CLUSTER", "ADDSLOTS", slots)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterAddSlotsRange assigns the slot ranges to the node the client is connected to.
+// Available since Redis 7.0.
+func (r *Redis) ClusterAddSlotsRange(ranges ...SlotRange) error {
+	args := packArgs("CLUSTER", "ADDSLOTSRANGE")
+	for _, sr := range ranges {
+		args = append(args, sr.Start, sr.End)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterDelSlots makes the node the client is connected to forget which master serves the slots.
+func (r *Redis) ClusterDelSlots(slots ...int) error {
+	args := packArgs("CLUSTER", "DELSLOTS", slots)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterDelSlotsRange is ClusterDelSlots with slot ranges.
+// Available since Redis 7.0.
+func (r *Redis) ClusterDelSlotsRange(ranges ...SlotRange) error {
+	args := packArgs("CLUSTER", "DELSLOTSRANGE")
+	for _, sr := range ranges {
+		args = append(args, sr.Start, sr.End)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterSetSlot changes the state of a hash slot in the node the client is connected to.
+// state is one of IMPORTING, MIGRATING, NODE which take a node id, and STABLE which does not.
+func (r *Redis) ClusterSetSlot(slot int, state, nodeID string) error {
+	args := packArgs("CLUSTER", "SETSLOT", slot, state)
+	if nodeID != "" {
+		args = append(args, nodeID)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterMeet connects the node the client is connected to with the node at host:port,
+// to join it into the cluster.
+func (r *Redis) ClusterMeet(host string, port int) error {
+	rp, err := r.ExecuteCommand("CLUSTER", "MEET", host, port)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterForget removes the node with nodeID from the nodes table of the node the client is connected to.
+func (r *Redis) ClusterForget(nodeID string) error {
+	rp, err := r.ExecuteCommand("CLUSTER", "FORGET", nodeID)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterReplicate reconfigures the node the client is connected to as a replica of the master nodeID.
+func (r *Redis) ClusterReplicate(nodeID string) error {
+	rp, err := r.ExecuteCommand("CLUSTER", "REPLICATE", nodeID)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterFailover forces the replica the client is connected to to start a manual failover of its master.
+// option is empty, FORCE or TAKEOVER.
+func (r *Redis) ClusterFailover(option string) error {
+	args := packArgs("CLUSTER", "FAILOVER")
+	if option != "" {
+		args = append(args, option)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClusterCountKeysInSlot returns the number of keys in the specified hash slot of the connected node.
+func (r *Redis) ClusterCountKeysInSlot(slot int) (int64, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "COUNTKEYSINSLOT", slot)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ClusterGetKeysInSlot returns at most count keys in the specified hash slot of the connected node.
+func (r *Redis) ClusterGetKeysInSlot(slot, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "GETKEYSINSLOT", slot, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
@@ -0,0 +1,126 @@
+package goredis
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeySlot(t *testing.T) {
+	if crc16([]byte("123456789")) != 0x31C3 {
+		t.Error("crc16")
+	}
+	slots := map[string]int{
+		"foo":                  12182,
+		"bar":                  5061,
+		"{user1000}.following": KeySlot("user1000"),
+		"{user1000}.followers": KeySlot("user1000"),
+		"foo{}{bar}":           KeySlot("foo{}{bar}"),
+		"foo{{bar}}zap":        KeySlot("{bar"),
+		"foo{bar}{zap}":        KeySlot("bar"),
+	}
+	for key, slot := range slots {
+		if KeySlot(key) != slot {
+			t.Errorf("slot of %s: %d", key, KeySlot(key))
+		}
+	}
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	s := "07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004,hostname4 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected\n" +
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460 5500 [5461->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1] [5462-<-292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f]\n"
+	nodes, err := parseClusterNodes(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 {
+		t.Fatal(nodes)
+	}
+	replica, master := nodes[0], nodes[1]
+	if replica.Address != "127.0.0.1:30004" || replica.BusPort != 31004 || replica.Hostname != "hostname4" {
+		t.Error(replica)
+	}
+	if !replica.HasFlag("slave") || replica.Master != master.ID || replica.PongRecv != 1426238317239 || replica.ConfigEpoch != 4 {
+		t.Error(replica)
+	}
+	if !master.HasFlag("myself") || master.Master != "" || master.LinkState != "connected" {
+		t.Error(master)
+	}
+	if len(master.Slots) != 2 || master.Slots[0] != (SlotRange{0, 5460}) || master.Slots[1] != (SlotRange{5500, 5500}) {
+		t.Error(master.Slots)
+	}
+	if master.Migrating[5461] != "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1" || master.Importing[5462] != "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f" {
+		t.Error(master.Migrating, master.Importing)
+	}
+	if _, err := parseClusterNodes("id 127.0.0.1:30001 master"); err == nil {
+		t.Fail()
+	}
+}
+
+func TestClusterShards(t *testing.T) {
+	node := func(id, ip string, port int, role, health string) []interface{} {
+		return []interface{}{"id", id, "port", port, "ip", ip, "endpoint", ip, "role", role, "replication-offset", 72156, "health", health}
+	}
+	s := newFakeServer(t, func(args []string) interface{} {
+		if len(args) != 2 || args[0] != "CLUSTER" {
+			return errors.New("ERR unknown command")
+		}
+		switch args[1] {
+		case "MYID":
+			return "m1"
+		case "SHARDS":
+			return []interface{}{
+				[]interface{}{
+					"slots", []interface{}{0, 5460},
+					"nodes", []interface{}{node("m2", "127.0.0.1", 30002, "master", "online")},
+				},
+				[]interface{}{
+					"slots", []interface{}{5461, 10922, 10923, 16383},
+					"nodes", []interface{}{
+						node("m1", "127.0.0.1", 30001, "master", "online"),
+						node("r1", "127.0.0.1", 30004, "replica", "online"),
+						node("r2", "127.0.0.1", 30005, "replica", "loading"),
+					},
+				},
+			}
+		}
+		return errors.New("ERR unknown subcommand")
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	shards, err := client.ClusterShards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards) != 2 || len(shards[1].Slots) != 2 || shards[1].Slots[1] != (SlotRange{10923, 16383}) {
+		t.Fatal(shards)
+	}
+	if n := shards[1].Nodes[1]; n.ID != "r1" || n.Port != 30004 || n.Role != "replica" || n.ReplicationOffset != 72156 || n.TLSPort != 0 {
+		t.Error(n)
+	}
+	if replicas, err := client.ClusterShardReplicas(); err != nil {
+		t.Error(err)
+	} else if len(replicas) != 1 || replicas[0] != "127.0.0.1:30004" {
+		t.Error(replicas)
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	s := newFakeServer(t, func(args []string) interface{} {
+		return "cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_known_nodes:6\r\n"
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	if info, err := client.ClusterInfo(); err != nil {
+		t.Error(err)
+	} else if info["cluster_state"] != "ok" || info["cluster_known_nodes"] != "6" {
+		t.Error(info)
+	}
+}
@@ -1,5 +1,10 @@
 package goredis
 
+import (
+	"container/list"
+	"errors"
+)
+
 // Echo command returns message.
 func (r *Redis) Echo(message string) (string, error) {
 	rp, err := r.ExecuteCommand("ECHO", message)
@@ -15,3 +20,44 @@ func (r *Redis) Ping() error {
 	_, err := r.ExecuteCommand("PING")
 	return err
 }
+
+// Session is a *Redis which sends all its commands on a single connection,
+// for commands like WAIT which act on the previous commands of the same connection.
+// A Session is not safe for concurrent use,
+// and once its connection is broken every command returns an error.
+type Session struct {
+	*Redis
+}
+
+// Session takes a connection out of the pool for a new *Session.
+func (r *Redis) Session() (*Session, error) {
+	c, err := r.pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	redis := *r
+	redis.route = nil
+	redis.fallback = nil
+	redis.pool = &connPool{
+		MaxIdle: 1,
+		Dial: func() (*connection, error) {
+			return nil, errors.New("session connection is busy or broken")
+		},
+		idle:  list.New(),
+		epoch: c.epoch,
+	}
+	redis.pool.Put(c)
+	return &Session{&redis}, nil
+}
+
+// Close closes the connection of the session.
+// It is not put back to the pool it was taken from,
+// since the state set on it, like CLIENT SETNAME, SELECT or an unfinished MULTI,
+// would carry over to the next users of the pool.
+func (s *Session) Close() {
+	c, err := s.pool.Get()
+	s.pool.Close()
+	if err == nil {
+		c.Conn.Close()
+	}
+}
@@ -19,6 +19,33 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestSession(t *testing.T) {
+	session, err := r.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.ClientSetName("session"); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < 3; i++ {
+		if name, err := session.ClientGetName(); err != nil {
+			t.Error(err)
+		} else if string(name) != "session" {
+			t.Fail()
+		}
+	}
+	if err := session.ClientSetName(""); err != nil {
+		t.Error(err)
+	}
+	session.Close()
+	if _, err := session.Echo("message"); err == nil {
+		t.Fail()
+	}
+	if name, err := r.ClientGetName(); err != nil || len(name) != 0 {
+		t.Error(string(name), err)
+	}
+}
+
 func BenchmarkPing(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		r.Ping()
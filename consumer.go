@@ -0,0 +1,176 @@
+package goredis
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of StreamConsumerConfig.
+const (
+	DefaultConsumerCount         = 10
+	DefaultConsumerBlock         = time.Second
+	DefaultConsumerClaimInterval = 30 * time.Second
+	DefaultConsumerMinIdle       = time.Minute
+)
+
+// StreamConsumerConfig is the parameters of a StreamConsumer.
+//
+// Handler is called once per entry, the entry is acknowledged when it returns nil.
+// An entry whose handler failed stays pending, and is retried once it has been idle for MinIdle.
+// Count is the number of entries read at once, and Block how long a read waits for new entries.
+// Every ClaimInterval the consumer claims the entries idle for MinIdle,
+// left pending by consumers which died or whose handler failed.
+// CreateGroup creates the stream and the group, reading only new entries, if they do not exist.
+type StreamConsumerConfig struct {
+	Stream        string
+	Group         string
+	Consumer      string
+	Handler       func(*StreamEntry) error
+	Count         int
+	Block         time.Duration
+	ClaimInterval time.Duration
+	MinIdle       time.Duration
+	CreateGroup   bool
+}
+
+// StreamConsumer reads the entries of a consumer group on a dedicated connection.
+type StreamConsumer struct {
+	redis  *Redis
+	config StreamConsumerConfig
+
+	mutex   sync.Mutex
+	stopped bool
+}
+
+// StreamConsumer new a *StreamConsumer reading cfg.Stream as cfg.Consumer of cfg.Group.
+func (r *Redis) StreamConsumer(cfg *StreamConsumerConfig) (*StreamConsumer, error) {
+	if cfg == nil || cfg.Stream == "" || cfg.Group == "" || cfg.Consumer == "" {
+		return nil, errors.New("consumer: stream, group and consumer are required")
+	}
+	if cfg.Handler == nil {
+		return nil, errors.New("consumer: no handler")
+	}
+	c := &StreamConsumer{redis: r, config: *cfg}
+	if c.config.Count == 0 {
+		c.config.Count = DefaultConsumerCount
+	}
+	if c.config.Block == 0 {
+		c.config.Block = DefaultConsumerBlock
+	}
+	if c.config.ClaimInterval == 0 {
+		c.config.ClaimInterval = DefaultConsumerClaimInterval
+	}
+	if c.config.MinIdle == 0 {
+		c.config.MinIdle = DefaultConsumerMinIdle
+	}
+	if c.config.CreateGroup {
+		err := r.XGroupCreate(c.config.Stream, c.config.Group, "$", true)
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Run consumes entries until Stop is called or a command fails.
+// It first handles the entries still pending for this consumer, then reads the new ones.
+// Run returns nil after Stop, within Block.
+func (c *StreamConsumer) Run() error {
+	session, err := c.redis.Session()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	// Entries delivered to this consumer before, read after the ID of the last one handled.
+	pending := "0"
+	lastClaim := time.Now()
+	for !c.isStopped() {
+		args := &XReadGroupArgs{
+			Group:    c.config.Group,
+			Consumer: c.config.Consumer,
+			Streams:  []string{c.config.Stream},
+			IDs:      []string{">"},
+			Count:    c.config.Count,
+		}
+		if pending != "" {
+			args.IDs[0] = pending
+		} else {
+			args.Block = true
+			args.Timeout = c.config.Block
+		}
+		streams, err := session.XReadGroup(args)
+		if err != nil {
+			return err
+		}
+		var entries []*StreamEntry
+		if len(streams) > 0 {
+			entries = streams[0].Entries
+		}
+		if pending != "" {
+			if len(entries) == 0 {
+				pending = ""
+			} else {
+				pending = entries[len(entries)-1].ID
+			}
+		}
+		if err := c.handle(session.Redis, entries); err != nil {
+			return err
+		}
+		if time.Since(lastClaim) >= c.config.ClaimInterval {
+			if err := c.claim(session.Redis); err != nil {
+				return err
+			}
+			lastClaim = time.Now()
+		}
+	}
+	return nil
+}
+
+// Stop makes Run return.
+func (c *StreamConsumer) Stop() {
+	c.mutex.Lock()
+	c.stopped = true
+	c.mutex.Unlock()
+}
+
+func (c *StreamConsumer) isStopped() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopped
+}
+
+// handle calls the handler for every entry and acknowledges the successful ones.
+func (c *StreamConsumer) handle(redis *Redis, entries []*StreamEntry) error {
+	var ids []string
+	for _, entry := range entries {
+		// Entries deleted from the stream while pending have no fields, nothing to handle.
+		if entry.Fields == nil || c.config.Handler(entry) == nil {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := redis.XAck(c.config.Stream, c.config.Group, ids...)
+	return err
+}
+
+// claim takes over and handles the entries idle for MinIdle.
+func (c *StreamConsumer) claim(redis *Redis) error {
+	start := "0-0"
+	for {
+		next, entries, err := redis.XAutoClaim(c.config.Stream, c.config.Group, c.config.Consumer, c.config.MinIdle, start, c.config.Count)
+		if err != nil {
+			return err
+		}
+		if err := c.handle(redis, entries); err != nil {
+			return err
+		}
+		if next == "0-0" || c.isStopped() {
+			return nil
+		}
+		start = next
+	}
+}
@@ -0,0 +1,75 @@
+package goredis
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamConsumer(t *testing.T) {
+	r.Del("stream")
+	r.XGroupCreate("stream", "group", "0", true)
+	r.XAdd("stream", map[string]string{"n": "1"}, &XAddArgs{ID: "1-1"})
+	// An entry left pending by a dead consumer.
+	r.XReadGroup(&XReadGroupArgs{Group: "group", Consumer: "dead", Streams: []string{"stream"}, IDs: []string{">"}})
+	r.XAdd("stream", map[string]string{"n": "2"}, &XAddArgs{ID: "2-1"})
+	r.XAdd("stream", map[string]string{"n": "fail"}, &XAddArgs{ID: "3-1"})
+
+	var mutex sync.Mutex
+	handled := make(map[string]int)
+	consumer, err := r.StreamConsumer(&StreamConsumerConfig{
+		Stream:   "stream",
+		Group:    "group",
+		Consumer: "consumer",
+		Handler: func(entry *StreamEntry) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			handled[entry.ID]++
+			if entry.Fields["n"] == "fail" {
+				return errors.New("fail")
+			}
+			return nil
+		},
+		Block:         50 * time.Millisecond,
+		ClaimInterval: 50 * time.Millisecond,
+		MinIdle:       time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- consumer.Run()
+	}()
+	time.Sleep(300 * time.Millisecond)
+	consumer.Stop()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if handled["1-1"] != 1 || handled["2-1"] != 1 || handled["3-1"] < 2 {
+		t.Error(handled)
+	}
+	if summary, err := r.XPending("stream", "group"); err != nil {
+		t.Error(err)
+	} else if summary.Count != 1 || summary.Consumers["consumer"] != 1 {
+		t.Fail()
+	}
+}
+
+func TestStreamConsumerCreateGroup(t *testing.T) {
+	r.Del("stream")
+	cfg := &StreamConsumerConfig{Stream: "stream", Group: "group", Consumer: "consumer", Handler: func(*StreamEntry) error { return nil }, CreateGroup: true}
+	if _, err := r.StreamConsumer(cfg); err != nil {
+		t.Error(err)
+	}
+	if _, err := r.StreamConsumer(cfg); err != nil {
+		t.Error(err)
+	}
+	cfg.Handler = nil
+	if _, err := r.StreamConsumer(cfg); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,240 @@
+package goredis
+
+import (
+	"errors"
+	"time"
+)
+
+// Policies of FunctionRestore, and modes of FunctionFlush.
+const (
+	FunctionRestoreAppend  = "APPEND"
+	FunctionRestoreReplace = "REPLACE"
+	FunctionRestoreFlush   = "FLUSH"
+
+	FlushAsync = "ASYNC"
+	FlushSync  = "SYNC"
+)
+
+// FunctionLoad loads a library of functions, whose code starts with a shebang like
+// #!lua name=mylib, and returns the name of the library.
+// With replace an existing library of the same name is replaced, else it is an error.
+// Available since Redis 7.0.
+func (r *Redis) FunctionLoad(code string, replace bool) (string, error) {
+	args := packArgs("FUNCTION", "LOAD")
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	args = append(args, code)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// FunctionDelete deletes the library and all its functions.
+func (r *Redis) FunctionDelete(library string) error {
+	rp, err := r.ExecuteCommand("FUNCTION", "DELETE", library)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionFlush deletes all the libraries, mode is FlushAsync, FlushSync or empty for the server default.
+func (r *Redis) FunctionFlush(mode string) error {
+	args := packArgs("FUNCTION", "FLUSH")
+	if mode != "" {
+		args = append(args, mode)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionKill kills the function currently executing,
+// assuming it performed no write operation yet.
+func (r *Redis) FunctionKill() error {
+	rp, err := r.ExecuteCommand("FUNCTION", "KILL")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionDump returns the serialized payload of all the libraries, for FunctionRestore.
+func (r *Redis) FunctionDump() ([]byte, error) {
+	rp, err := r.ExecuteCommand("FUNCTION", "DUMP")
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
+// FunctionRestore restores the libraries of a FunctionDump payload.
+// policy is FunctionRestoreAppend, the default if empty, which fails on a library already loaded,
+// FunctionRestoreReplace which replaces such libraries,
+// or FunctionRestoreFlush which deletes all the existing libraries first.
+func (r *Redis) FunctionRestore(payload []byte, policy string) error {
+	args := []interface{}{"FUNCTION", "RESTORE", payload}
+	if policy != "" {
+		args = append(args, policy)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionLibrary is a library of FunctionList.
+// Code is only set with withCode.
+type FunctionLibrary struct {
+	Name      string
+	Engine    string
+	Functions []FunctionInfo
+	Code      string
+}
+
+// FunctionInfo is a function of a FunctionLibrary.
+// Flags are the flags declared with the function, like no-writes.
+type FunctionInfo struct {
+	Name        string
+	Description string
+	Flags       []string
+}
+
+// FunctionList returns the libraries whose name matches pattern, all of them if pattern is empty,
+// with their code if withCode.
+func (r *Redis) FunctionList(pattern string, withCode bool) ([]*FunctionLibrary, error) {
+	args := packArgs("FUNCTION", "LIST")
+	if pattern != "" {
+		args = append(args, "LIBRARYNAME", pattern)
+	}
+	if withCode {
+		args = append(args, "WITHCODE")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	libraries := make([]*FunctionLibrary, len(multi))
+	for i, subrp := range multi {
+		if subrp.Type != MultiReply {
+			return nil, errors.New("function list protocol error")
+		}
+		library := &FunctionLibrary{}
+		if library.Name, err = subrp.field("library_name").StringValue(); err != nil {
+			return nil, err
+		}
+		if library.Engine, err = subrp.field("engine").StringValue(); err != nil {
+			return nil, err
+		}
+		if library.Code, err = subrp.field("library_code").StringValue(); err != nil {
+			return nil, err
+		}
+		functions := subrp.field("functions")
+		for _, frp := range functions.Multi {
+			var function FunctionInfo
+			if function.Name, err = frp.field("name").StringValue(); err != nil {
+				return nil, err
+			}
+			if function.Description, err = frp.field("description").StringValue(); err != nil {
+				return nil, err
+			}
+			if flags := frp.field("flags"); flags.Type == MultiReply {
+				if function.Flags, err = flags.ListValue(); err != nil {
+					return nil, err
+				}
+			}
+			library.Functions = append(library.Functions, function)
+		}
+		libraries[i] = library
+	}
+	return libraries, nil
+}
+
+// FunctionStats is the reply of FUNCTION STATS.
+// Running is the function currently executing, nil if none.
+// Engines are the numbers of libraries and functions by engine, like LUA.
+type FunctionStats struct {
+	Running *RunningFunction
+	Engines map[string]FunctionEngineStats
+}
+
+// RunningFunction is the function currently executing.
+// Command is the FCALL command which called it.
+type RunningFunction struct {
+	Name     string
+	Command  []string
+	Duration time.Duration
+}
+
+// FunctionEngineStats is the numbers of libraries and functions of an engine.
+type FunctionEngineStats struct {
+	Libraries int64
+	Functions int64
+}
+
+// FunctionStats returns the function currently executing and the libraries loaded by engine.
+func (r *Redis) FunctionStats() (*FunctionStats, error) {
+	rp, err := r.ExecuteCommand("FUNCTION", "STATS")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rp.MultiValue(); err != nil {
+		return nil, err
+	}
+	stats := &FunctionStats{Engines: make(map[string]FunctionEngineStats)}
+	if running := rp.field("running_script"); running.Type == MultiReply {
+		stats.Running = &RunningFunction{}
+		if stats.Running.Name, err = running.field("name").StringValue(); err != nil {
+			return nil, err
+		}
+		if stats.Running.Command, err = running.field("command").ListValue(); err != nil {
+			return nil, err
+		}
+		ms, err := running.field("duration_ms").IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		stats.Running.Duration = time.Duration(ms) * time.Millisecond
+	}
+	engines := rp.field("engines")
+	for i := 0; i+1 < len(engines.Multi); i += 2 {
+		name, err := engines.Multi[i].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		var engine FunctionEngineStats
+		if engine.Libraries, err = engines.Multi[i+1].field("libraries_count").IntegerValue(); err != nil {
+			return nil, err
+		}
+		if engine.Functions, err = engines.Multi[i+1].field("functions_count").IntegerValue(); err != nil {
+			return nil, err
+		}
+		stats.Engines[name] = engine
+	}
+	return stats, nil
+}
+
+// FCall calls the function, loaded with FunctionLoad, like Eval calls a script.
+// args are packed like the ones of Eval.
+// Available since Redis 7.0.
+func (r *Redis) FCall(function string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("FCALL", function, len(keys), keys, scriptArgs(args))
+	return r.ExecuteCommand(cmds...)
+}
+
+// FCallRO is the read-only variant of FCall, for functions flagged no-writes,
+// which can run on replicas.
+func (r *Redis) FCallRO(function string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("FCALL_RO", function, len(keys), keys, scriptArgs(args))
+	return r.ExecuteCommand(cmds...)
+}
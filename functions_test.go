@@ -0,0 +1,114 @@
+package goredis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFunctions(t *testing.T) {
+	var got []string
+	s := newFakeServer(t, func(args []string) interface{} {
+		got = args
+		switch strings.Join(args[:2], " ") {
+		case "FUNCTION LOAD":
+			if args[2] != "REPLACE" {
+				return errors.New("ERR Library 'mylib' already exists")
+			}
+			return "mylib"
+		case "FUNCTION LIST":
+			return []interface{}{
+				[]interface{}{
+					"library_name", "mylib", "engine", "LUA",
+					"functions", []interface{}{
+						[]interface{}{"name", "myfunc", "description", nil, "flags", []string{"no-writes"}},
+						[]interface{}{"name", "other", "description", "does things", "flags", []string{}},
+					},
+					"library_code", "#!lua name=mylib",
+				},
+			}
+		case "FUNCTION STATS":
+			return []interface{}{
+				"running_script", []interface{}{"name", "myfunc", "command", []string{"fcall", "myfunc", "0"}, "duration_ms", 1500},
+				"engines", []interface{}{"LUA", []interface{}{"libraries_count", 1, "functions_count", 2}},
+			}
+		case "FUNCTION DUMP":
+			return "\xf5\xc3payload"
+		case "FUNCTION RESTORE", "FUNCTION DELETE", "FUNCTION FLUSH", "FUNCTION KILL":
+			return fakeStatus("OK")
+		case "FCALL myfunc", "FCALL_RO myfunc":
+			return 42
+		}
+		return errors.New("ERR unknown command")
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+
+	if _, err := client.FunctionLoad("#!lua name=mylib", false); err == nil {
+		t.Error("load over an existing library")
+	}
+	if name, err := client.FunctionLoad("#!lua name=mylib", true); err != nil || name != "mylib" {
+		t.Error(name, err)
+	}
+	libraries, err := client.FunctionList("my*", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "FUNCTION LIST LIBRARYNAME my* WITHCODE" {
+		t.Error(got)
+	}
+	if len(libraries) != 1 || libraries[0].Name != "mylib" || libraries[0].Engine != "LUA" || libraries[0].Code != "#!lua name=mylib" {
+		t.Fatal(libraries)
+	}
+	if fs := libraries[0].Functions; len(fs) != 2 || fs[0].Name != "myfunc" || fs[0].Description != "" ||
+		len(fs[0].Flags) != 1 || fs[0].Flags[0] != "no-writes" || fs[1].Description != "does things" {
+		t.Error(fs)
+	}
+	stats, err := client.FunctionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Running == nil || stats.Running.Name != "myfunc" || len(stats.Running.Command) != 3 || stats.Running.Duration != 1500*time.Millisecond {
+		t.Error(stats.Running)
+	}
+	if stats.Engines["LUA"] != (FunctionEngineStats{1, 2}) {
+		t.Error(stats.Engines)
+	}
+	payload, err := client.FunctionDump()
+	if err != nil || string(payload) != "\xf5\xc3payload" {
+		t.Fatal(payload, err)
+	}
+	if err := client.FunctionRestore(payload, FunctionRestoreReplace); err != nil {
+		t.Error(err)
+	}
+	if got[2] != "\xf5\xc3payload" || got[3] != "REPLACE" {
+		t.Error(got)
+	}
+	if err := client.FunctionDelete("mylib"); err != nil {
+		t.Error(err)
+	}
+	if err := client.FunctionFlush(FlushAsync); err != nil || got[2] != "ASYNC" {
+		t.Error(got, err)
+	}
+	if err := client.FunctionKill(); err != nil {
+		t.Error(err)
+	}
+	rp, err := client.FCall("myfunc", []string{"key"}, "arg", 2, []byte("bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := rp.IntegerValue(); n != 42 || strings.Join(got, " ") != "FCALL myfunc 1 key arg 2 bin" {
+		t.Error(n, got)
+	}
+	if _, err := client.FCallRO("myfunc", nil, []string{"a", "b"}); err != nil || strings.Join(got, " ") != "FCALL_RO myfunc 0 a b" {
+		t.Error(got, err)
+	}
+	if _, err := client.FCallRO("myfunc", nil); err != nil || len(got) != 3 {
+		t.Error(got, err)
+	}
+}
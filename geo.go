@@ -0,0 +1,272 @@
+package goredis
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Distance units of the GEO commands.
+const (
+	GeoMeters     = "m"
+	GeoKilometers = "km"
+	GeoMiles      = "mi"
+	GeoFeet       = "ft"
+)
+
+// GeoLocation is a member of a geospatial index.
+// Dist and GeoHash are only set by GeoSearch with WithDist and WithHash.
+type GeoLocation struct {
+	Name      string
+	Longitude float64
+	Latitude  float64
+	Dist      float64
+	GeoHash   int64
+}
+
+// GeoAddArgs is the options of GEOADD.
+// NX only adds new members, XX only updates existing ones,
+// and CH counts the updated members in the reply too.
+type GeoAddArgs struct {
+	NX bool
+	XX bool
+	CH bool
+}
+
+// GeoAdd adds the locations to the geospatial index stored at key, args may be nil.
+// Integer reply: the number of members added, or changed with CH.
+func (r *Redis) GeoAdd(key string, args *GeoAddArgs, locations ...GeoLocation) (int64, error) {
+	cmds := packArgs("GEOADD", key)
+	if args != nil {
+		if args.NX {
+			cmds = append(cmds, "NX")
+		}
+		if args.XX {
+			cmds = append(cmds, "XX")
+		}
+		if args.CH {
+			cmds = append(cmds, "CH")
+		}
+	}
+	for _, location := range locations {
+		cmds = append(cmds, location.Longitude, location.Latitude, location.Name)
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// GeoPos returns the positions of members, nil for the members which do not exist.
+func (r *Redis) GeoPos(key string, members ...string) ([]*GeoLocation, error) {
+	args := packArgs("GEOPOS", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if len(multi) != len(members) {
+		return nil, errors.New("geopos protocol error")
+	}
+	locations := make([]*GeoLocation, len(members))
+	for i, subrp := range multi {
+		if subrp.Type != MultiReply || subrp.Multi == nil {
+			continue
+		}
+		location := &GeoLocation{Name: members[i]}
+		if err := geoCoordValue(subrp, location); err != nil {
+			return nil, err
+		}
+		locations[i] = location
+	}
+	return locations, nil
+}
+
+// GeoDist returns the distance between two members in unit, meters if unit is empty.
+// Returns -1 if one or both members do not exist.
+func (r *Redis) GeoDist(key, member1, member2, unit string) (float64, error) {
+	args := packArgs("GEODIST", key, member1, member2)
+	if unit != "" {
+		args = append(args, unit)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	b, err := rp.BytesValue()
+	if err != nil {
+		return 0, err
+	}
+	if b == nil {
+		return -1, nil
+	}
+	return strconv.ParseFloat(string(b), 64)
+}
+
+// GeoHash returns the Geohash strings of members, empty for the members which do not exist.
+func (r *Redis) GeoHash(key string, members ...string) ([]string, error) {
+	args := packArgs("GEOHASH", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// GeoSearchArgs is the arguments of GEOSEARCH and GEOSEARCHSTORE.
+//
+// The search is centered on Member (FROMMEMBER), or on Longitude and Latitude (FROMLONLAT) if Member is empty.
+// The area is a circle of Radius (BYRADIUS) if Radius is set, else a Width x Height box (BYBOX),
+// both in Unit, meters if Unit is empty.
+// Sort is ASC, DESC or empty for unsorted results.
+// Count limits the results, with Any the first Count matches are returned without looking for the nearest.
+// WithCoord, WithDist and WithHash fill the matching fields of GeoLocation, they are ignored by GeoSearchStore.
+type GeoSearchArgs struct {
+	Member    string
+	Longitude float64
+	Latitude  float64
+	Radius    float64
+	Width     float64
+	Height    float64
+	Unit      string
+	Sort      string
+	Count     int
+	Any       bool
+	WithCoord bool
+	WithDist  bool
+	WithHash  bool
+}
+
+func (a *GeoSearchArgs) args() []interface{} {
+	var args []interface{}
+	if a.Member != "" {
+		args = append(args, "FROMMEMBER", a.Member)
+	} else {
+		args = append(args, "FROMLONLAT", a.Longitude, a.Latitude)
+	}
+	unit := a.Unit
+	if unit == "" {
+		unit = GeoMeters
+	}
+	if a.Radius > 0 {
+		args = append(args, "BYRADIUS", a.Radius, unit)
+	} else {
+		args = append(args, "BYBOX", a.Width, a.Height, unit)
+	}
+	if a.Sort != "" {
+		args = append(args, a.Sort)
+	}
+	if a.Count > 0 {
+		args = append(args, "COUNT", a.Count)
+		if a.Any {
+			args = append(args, "ANY")
+		}
+	}
+	return args
+}
+
+// GeoSearch returns the members of the geospatial index stored at key inside the area of args.
+// Available since Redis 6.2.
+func (r *Redis) GeoSearch(key string, args *GeoSearchArgs) ([]GeoLocation, error) {
+	cmds := packArgs("GEOSEARCH", key, args.args())
+	if args.WithCoord {
+		cmds = append(cmds, "WITHCOORD")
+	}
+	if args.WithDist {
+		cmds = append(cmds, "WITHDIST")
+	}
+	if args.WithHash {
+		cmds = append(cmds, "WITHHASH")
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]GeoLocation, len(multi))
+	for i, subrp := range multi {
+		if !args.WithCoord && !args.WithDist && !args.WithHash {
+			if locations[i].Name, err = subrp.StringValue(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// The name comes first, then the distance, the hash and the coordinates.
+		if subrp.Type != MultiReply || len(subrp.Multi) == 0 {
+			return nil, errors.New("geosearch protocol error")
+		}
+		fields := subrp.Multi
+		if locations[i].Name, err = fields[0].StringValue(); err != nil {
+			return nil, err
+		}
+		fields = fields[1:]
+		if args.WithDist {
+			if len(fields) == 0 {
+				return nil, errors.New("geosearch protocol error")
+			}
+			if locations[i].Dist, err = geoFloatValue(fields[0]); err != nil {
+				return nil, err
+			}
+			fields = fields[1:]
+		}
+		if args.WithHash {
+			if len(fields) == 0 {
+				return nil, errors.New("geosearch protocol error")
+			}
+			if locations[i].GeoHash, err = fields[0].IntegerValue(); err != nil {
+				return nil, err
+			}
+			fields = fields[1:]
+		}
+		if args.WithCoord {
+			if len(fields) == 0 {
+				return nil, errors.New("geosearch protocol error")
+			}
+			if err := geoCoordValue(fields[0], &locations[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return locations, nil
+}
+
+// GeoSearchStore is GeoSearch storing the members found into destination.
+// With storeDist the distances are stored as the scores instead of the positions.
+// Integer reply: the number of members in destination.
+func (r *Redis) GeoSearchStore(destination, source string, args *GeoSearchArgs, storeDist bool) (int64, error) {
+	cmds := packArgs("GEOSEARCHSTORE", destination, source, args.args())
+	if storeDist {
+		cmds = append(cmds, "STOREDIST")
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+func geoFloatValue(rp *Reply) (float64, error) {
+	s, err := rp.StringValue()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func geoCoordValue(rp *Reply, location *GeoLocation) error {
+	if rp.Type != MultiReply || len(rp.Multi) != 2 {
+		return errors.New("geo coordinates protocol error")
+	}
+	var err error
+	if location.Longitude, err = geoFloatValue(rp.Multi[0]); err != nil {
+		return err
+	}
+	location.Latitude, err = geoFloatValue(rp.Multi[1])
+	return err
+}
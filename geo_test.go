@@ -0,0 +1,100 @@
+package goredis
+
+import (
+	"math"
+	"testing"
+)
+
+var sicily = []GeoLocation{
+	{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+	{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+}
+
+func TestGeoAdd(t *testing.T) {
+	r.Del("Sicily")
+	if n, err := r.GeoAdd("Sicily", nil, sicily...); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Fail()
+	}
+	moved := GeoLocation{Name: "Palermo", Longitude: 13.5, Latitude: 38.1}
+	if n, err := r.GeoAdd("Sicily", &GeoAddArgs{NX: true}, moved); err != nil {
+		t.Error(err)
+	} else if n != 0 {
+		t.Fail()
+	}
+	if n, err := r.GeoAdd("Sicily", &GeoAddArgs{XX: true, CH: true}, moved); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
+func TestGeoPos(t *testing.T) {
+	r.Del("Sicily")
+	r.GeoAdd("Sicily", nil, sicily...)
+	if locations, err := r.GeoPos("Sicily", "Palermo", "NonExisting"); err != nil {
+		t.Error(err)
+	} else if len(locations) != 2 || locations[1] != nil || math.Abs(locations[0].Longitude-13.361389) > 0.0001 {
+		t.Fail()
+	}
+}
+
+func TestGeoDist(t *testing.T) {
+	r.Del("Sicily")
+	r.GeoAdd("Sicily", nil, sicily...)
+	if dist, err := r.GeoDist("Sicily", "Palermo", "Catania", GeoKilometers); err != nil {
+		t.Error(err)
+	} else if math.Abs(dist-166.2742) > 0.01 {
+		t.Fail()
+	}
+	if dist, err := r.GeoDist("Sicily", "Palermo", "NonExisting", ""); err != nil {
+		t.Error(err)
+	} else if dist != -1 {
+		t.Fail()
+	}
+}
+
+func TestGeoHash(t *testing.T) {
+	r.Del("Sicily")
+	r.GeoAdd("Sicily", nil, sicily...)
+	if hashes, err := r.GeoHash("Sicily", "Palermo", "Catania"); err != nil {
+		t.Error(err)
+	} else if len(hashes) != 2 || hashes[0] != "sqc8b49rny0" || hashes[1] != "sqdtr74hyu0" {
+		t.Fail()
+	}
+}
+
+func TestGeoSearch(t *testing.T) {
+	r.Del("Sicily")
+	r.GeoAdd("Sicily", nil, sicily...)
+	args := &GeoSearchArgs{Longitude: 15, Latitude: 37, Radius: 200, Unit: GeoKilometers, Sort: "ASC"}
+	if locations, err := r.GeoSearch("Sicily", args); err != nil {
+		t.Error(err)
+	} else if len(locations) != 2 || locations[0].Name != "Catania" || locations[1].Name != "Palermo" {
+		t.Fail()
+	}
+	args.WithCoord, args.WithDist, args.WithHash = true, true, true
+	if locations, err := r.GeoSearch("Sicily", args); err != nil {
+		t.Error(err)
+	} else if len(locations) != 2 || math.Abs(locations[0].Dist-56.4413) > 0.01 || locations[0].GeoHash == 0 || math.Abs(locations[0].Latitude-37.502669) > 0.0001 {
+		t.Fail()
+	}
+	box := &GeoSearchArgs{Member: "Palermo", Width: 100, Height: 100, Unit: GeoKilometers, Count: 1}
+	if locations, err := r.GeoSearch("Sicily", box); err != nil {
+		t.Error(err)
+	} else if len(locations) != 1 || locations[0].Name != "Palermo" {
+		t.Fail()
+	}
+}
+
+func TestGeoSearchStore(t *testing.T) {
+	r.Del("Sicily", "near")
+	r.GeoAdd("Sicily", nil, sicily...)
+	args := &GeoSearchArgs{Longitude: 15, Latitude: 37, Radius: 100, Unit: GeoKilometers}
+	if n, err := r.GeoSearchStore("near", "Sicily", args, true); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package goredis
 
 import (
+	"errors"
 	"strconv"
 )
 
@@ -171,6 +172,7 @@ func (r *Redis) HVals(key string) ([]string, error) {
 
 // HScan command:
 // HSCAN key cursor [MATCH pattern] [COUNT count]
+// The fields of a batch are returned as a map, use HScanIterator to keep their order.
 func (r *Redis) HScan(key string, cursor uint64, pattern string, count int) (uint64, map[string]string, error) {
 	args := packArgs("HSCAN", key, cursor)
 	if pattern != "" {
@@ -183,14 +185,311 @@ func (r *Redis) HScan(key string, cursor uint64, pattern string, count int) (uin
 	if err != nil {
 		return 0, nil, err
 	}
-	first, err := rp.Multi[0].StringValue()
+	next, list, err := scanValue(rp)
 	if err != nil {
 		return 0, nil, err
 	}
-	next, err := strconv.ParseUint(first, 10, 64)
+	if len(list)%2 != 0 {
+		return 0, nil, errors.New("hscan protocol error")
+	}
+	hash := make(map[string]string, len(list)/2)
+	for i := 0; i < len(list); i += 2 {
+		hash[list[i]] = list[i+1]
+	}
+	return next, hash, nil
+}
+
+// HStrLen command:
+// Returns the string length of the value associated with field in the hash stored at key.
+// Integer reply: the length, or 0 when field or key does not exist.
+// Available since Redis 3.2.
+func (r *Redis) HStrLen(key, field string) (int64, error) {
+	rp, err := r.ExecuteCommand("HSTRLEN", key, field)
 	if err != nil {
-		return 0, nil, err
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// HashField is a field of a hash with its value.
+type HashField struct {
+	Field string
+	Value string
+}
+
+// HRandField command:
+// Returns random fields from the hash stored at key, one if count is 0.
+// With a positive count the fields are distinct,
+// with a negative count the same field may be returned multiple times.
+// Available since Redis 6.2.
+func (r *Redis) HRandField(key string, count int) ([]string, error) {
+	if count == 0 {
+		rp, err := r.ExecuteCommand("HRANDFIELD", key)
+		if err != nil {
+			return nil, err
+		}
+		b, err := rp.BytesValue()
+		if err != nil || b == nil {
+			return nil, err
+		}
+		return []string{string(b)}, nil
+	}
+	rp, err := r.ExecuteCommand("HRANDFIELD", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// HRandFieldWithValues command:
+// HRandField with WITHVALUES, count can not be 0.
+func (r *Redis) HRandFieldWithValues(key string, count int) ([]HashField, error) {
+	rp, err := r.ExecuteCommand("HRANDFIELD", key, count, "WITHVALUES")
+	if err != nil {
+		return nil, err
+	}
+	return hashFieldsValue(rp.ListValue())
+}
+
+func hashFieldsValue(list []string, err error) ([]HashField, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(list)%2 != 0 {
+		return nil, errors.New("field value list protocol error")
+	}
+	fields := make([]HashField, len(list)/2)
+	for i := range fields {
+		fields[i] = HashField{list[2*i], list[2*i+1]}
+	}
+	return fields, nil
+}
+
+// HFieldStatus is the per-field result of the hash field expiration commands.
+type HFieldStatus int64
+
+// Values of HFieldStatus.
+const (
+	// HFieldNotFound means the field, or the key, does not exist.
+	HFieldNotFound HFieldStatus = -2
+	// HFieldNoTTL means HPersist found no expiration on the field.
+	HFieldNoTTL HFieldStatus = -1
+	// HFieldNotUpdated means the NX, XX, GT or LT condition was not met.
+	HFieldNotUpdated HFieldStatus = 0
+	// HFieldUpdated means the expiration was set, or removed by HPersist.
+	HFieldUpdated HFieldStatus = 1
+	// HFieldDeleted means the expiration was in the past and the field was deleted.
+	HFieldDeleted HFieldStatus = 2
+)
+
+func (r *Redis) hExpire(cmd, key string, value interface{}, condition string, fields []string) ([]HFieldStatus, error) {
+	args := packArgs(cmd, key, value)
+	if condition != "" {
+		args = append(args, condition)
+	}
+	args = append(args, "FIELDS", len(fields))
+	rp, err := r.ExecuteCommand(packArgs(args, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return hFieldStatusValue(rp)
+}
+
+func hFieldStatusValue(rp *Reply) ([]HFieldStatus, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]HFieldStatus, len(multi))
+	for i, subrp := range multi {
+		n, err := subrp.IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = HFieldStatus(n)
+	}
+	return result, nil
+}
+
+// HExpire command:
+// Sets a timeout of seconds on fields of the hash stored at key,
+// condition is one of NX, XX, GT, LT or empty.
+// It returns a status for each field.
+// Available since Redis 7.4.
+func (r *Redis) HExpire(key string, seconds int, condition string, fields ...string) ([]HFieldStatus, error) {
+	return r.hExpire("HEXPIRE", key, seconds, condition, fields)
+}
+
+// HPExpire command:
+// Like HExpire with the timeout in milliseconds.
+func (r *Redis) HPExpire(key string, milliseconds int, condition string, fields ...string) ([]HFieldStatus, error) {
+	return r.hExpire("HPEXPIRE", key, milliseconds, condition, fields)
+}
+
+// HExpireAt command:
+// Like HExpire with an absolute Unix timestamp in seconds.
+func (r *Redis) HExpireAt(key string, timestamp int64, condition string, fields ...string) ([]HFieldStatus, error) {
+	return r.hExpire("HEXPIREAT", key, timestamp, condition, fields)
+}
+
+// HPExpireAt command:
+// Like HExpire with an absolute Unix timestamp in milliseconds.
+func (r *Redis) HPExpireAt(key string, timestamp int64, condition string, fields ...string) ([]HFieldStatus, error) {
+	return r.hExpire("HPEXPIREAT", key, timestamp, condition, fields)
+}
+
+// HPersist command:
+// Removes the expiration of fields of the hash stored at key,
+// HFieldUpdated for the fields whose expiration was removed.
+// Available since Redis 7.4.
+func (r *Redis) HPersist(key string, fields ...string) ([]HFieldStatus, error) {
+	args := packArgs("HPERSIST", key, "FIELDS", len(fields), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return hFieldStatusValue(rp)
+}
+
+func (r *Redis) hTTL(cmd, key string, fields []string) ([]int64, error) {
+	args := packArgs(cmd, key, "FIELDS", len(fields), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int64, len(multi))
+	for i, subrp := range multi {
+		if result[i], err = subrp.IntegerValue(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// HTTL command:
+// Returns the remaining time to live in seconds of fields of the hash stored at key,
+// -1 for a field without expiration and -2 for a field which does not exist.
+// Available since Redis 7.4.
+func (r *Redis) HTTL(key string, fields ...string) ([]int64, error) {
+	return r.hTTL("HTTL", key, fields)
+}
+
+// HPTTL command:
+// Like HTTL in milliseconds.
+func (r *Redis) HPTTL(key string, fields ...string) ([]int64, error) {
+	return r.hTTL("HPTTL", key, fields)
+}
+
+// HExpireTime command:
+// Returns the absolute Unix timestamp in seconds at which fields of the hash stored at key expire,
+// -1 for a field without expiration and -2 for a field which does not exist.
+// Available since Redis 7.4.
+func (r *Redis) HExpireTime(key string, fields ...string) ([]int64, error) {
+	return r.hTTL("HEXPIRETIME", key, fields)
+}
+
+// HPExpireTime command:
+// Like HExpireTime in milliseconds.
+func (r *Redis) HPExpireTime(key string, fields ...string) ([]int64, error) {
+	return r.hTTL("HPEXPIRETIME", key, fields)
+}
+
+// HGetDel command:
+// Returns and deletes fields of the hash stored at key, nil for the fields which do not exist.
+// The key is deleted when its last field is.
+// Available since Redis 8.0.
+func (r *Redis) HGetDel(key string, fields ...string) ([][]byte, error) {
+	args := packArgs("HGETDEL", key, "FIELDS", len(fields), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
 	}
-	hash, err := rp.Multi[1].HashValue()
-	return next, hash, err
+	return rp.BytesArrayValue()
+}
+
+// HGetExArgs is the expiration options of HGETEX, at most one of them may be set.
+// EX and PX are timeouts in seconds and milliseconds, EXAT and PXAT Unix timestamps in seconds and milliseconds.
+// Persist removes the expiration of the fields.
+type HGetExArgs struct {
+	EX      int
+	PX      int
+	EXAT    int64
+	PXAT    int64
+	Persist bool
+}
+
+// HGetEx command:
+// Returns fields of the hash stored at key like HMGet, and sets or removes their expiration. args may be nil.
+// Available since Redis 8.0.
+func (r *Redis) HGetEx(key string, args *HGetExArgs, fields ...string) ([][]byte, error) {
+	cmds := packArgs("HGETEX", key)
+	if args != nil {
+		switch {
+		case args.EX > 0:
+			cmds = append(cmds, "EX", args.EX)
+		case args.PX > 0:
+			cmds = append(cmds, "PX", args.PX)
+		case args.EXAT > 0:
+			cmds = append(cmds, "EXAT", args.EXAT)
+		case args.PXAT > 0:
+			cmds = append(cmds, "PXAT", args.PXAT)
+		case args.Persist:
+			cmds = append(cmds, "PERSIST")
+		}
+	}
+	rp, err := r.ExecuteCommand(packArgs(cmds, "FIELDS", len(fields), fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// HSetExArgs is the options of HSETEX.
+// FNX only sets the fields if none of them exists, FXX only if all of them exist.
+// At most one expiration option may be set, like HGetExArgs,
+// KeepTTL keeps the current expiration of the fields.
+type HSetExArgs struct {
+	FNX     bool
+	FXX     bool
+	EX      int
+	PX      int
+	EXAT    int64
+	PXAT    int64
+	KeepTTL bool
+}
+
+// HSetEx command:
+// Sets fields of the hash stored at key and their expiration. args may be nil.
+// False if no field was set because of FNX or FXX.
+// Available since Redis 8.0.
+func (r *Redis) HSetEx(key string, args *HSetExArgs, pairs map[string]string) (bool, error) {
+	cmds := packArgs("HSETEX", key)
+	if args != nil {
+		if args.FNX {
+			cmds = append(cmds, "FNX")
+		} else if args.FXX {
+			cmds = append(cmds, "FXX")
+		}
+		switch {
+		case args.EX > 0:
+			cmds = append(cmds, "EX", args.EX)
+		case args.PX > 0:
+			cmds = append(cmds, "PX", args.PX)
+		case args.EXAT > 0:
+			cmds = append(cmds, "EXAT", args.EXAT)
+		case args.PXAT > 0:
+			cmds = append(cmds, "PXAT", args.PXAT)
+		case args.KeepTTL:
+			cmds = append(cmds, "KEEPTTL")
+		}
+	}
+	rp, err := r.ExecuteCommand(packArgs(cmds, "FIELDS", len(pairs), pairs)...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
 }
@@ -163,3 +163,142 @@ func TestHScan(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestHStrLen(t *testing.T) {
+	r.Del("key")
+	r.HSet("key", "field", "value")
+	if n, err := r.HStrLen("key", "field"); err != nil {
+		t.Error(err)
+	} else if n != 5 {
+		t.Fail()
+	}
+	if n, _ := r.HStrLen("key", "nofield"); n != 0 {
+		t.Fail()
+	}
+}
+
+func TestHRandField(t *testing.T) {
+	r.Del("key")
+	if fields, err := r.HRandField("key", 0); err != nil {
+		t.Error(err)
+	} else if fields != nil {
+		t.Fail()
+	}
+	r.HMSet("key", map[string]string{"a": "1", "b": "2"})
+	if fields, err := r.HRandField("key", 0); err != nil {
+		t.Error(err)
+	} else if len(fields) != 1 {
+		t.Fail()
+	}
+	if fields, err := r.HRandField("key", -3); err != nil {
+		t.Error(err)
+	} else if len(fields) != 3 {
+		t.Fail()
+	}
+	if fields, err := r.HRandFieldWithValues("key", 5); err != nil {
+		t.Error(err)
+	} else if len(fields) != 2 || (fields[0].Field == "a" && fields[0].Value != "1") {
+		t.Fail()
+	}
+}
+
+func TestHExpire(t *testing.T) {
+	r.Del("key")
+	r.HMSet("key", map[string]string{"a": "1", "b": "2"})
+	if result, err := r.HExpire("key", 100, "", "a", "nofield"); err != nil {
+		t.Error(err)
+	} else if len(result) != 2 || result[0] != HFieldUpdated || result[1] != HFieldNotFound {
+		t.Fail()
+	}
+	if result, err := r.HPExpire("key", 50000, "GT", "a"); err != nil {
+		t.Error(err)
+	} else if len(result) != 1 || result[0] != HFieldNotUpdated {
+		t.Fail()
+	}
+	if ttl, err := r.HTTL("key", "a", "b", "nofield"); err != nil {
+		t.Error(err)
+	} else if len(ttl) != 3 || ttl[0] <= 0 || ttl[1] != -1 || ttl[2] != -2 {
+		t.Fail()
+	}
+	if ttl, err := r.HPTTL("key", "a"); err != nil {
+		t.Error(err)
+	} else if len(ttl) != 1 || ttl[0] <= 1000 {
+		t.Fail()
+	}
+	if result, err := r.HPersist("key", "a", "b"); err != nil {
+		t.Error(err)
+	} else if len(result) != 2 || result[0] != HFieldUpdated || result[1] != HFieldNoTTL {
+		t.Fail()
+	}
+	if result, err := r.HExpireAt("key", 1, "", "b"); err != nil {
+		t.Error(err)
+	} else if len(result) != 1 || result[0] != HFieldDeleted {
+		t.Fail()
+	}
+}
+
+func TestHExpireTime(t *testing.T) {
+	r.Del("key")
+	r.HSet("key", "field", "value")
+	r.HPExpireAt("key", 33177117420000, "", "field")
+	if times, err := r.HExpireTime("key", "field", "nofield"); err != nil {
+		t.Error(err)
+	} else if len(times) != 2 || times[0] != 33177117420 || times[1] != -2 {
+		t.Fail()
+	}
+	if times, err := r.HPExpireTime("key", "field"); err != nil {
+		t.Error(err)
+	} else if len(times) != 1 || times[0] != 33177117420000 {
+		t.Fail()
+	}
+}
+
+func TestHGetDel(t *testing.T) {
+	r.Del("key")
+	r.HMSet("key", map[string]string{"a": "1", "b": "2"})
+	if values, err := r.HGetDel("key", "a", "nofield"); err != nil {
+		t.Error(err)
+	} else if len(values) != 2 || string(values[0]) != "1" || values[1] != nil {
+		t.Fail()
+	}
+	if n, _ := r.HLen("key"); n != 1 {
+		t.Fail()
+	}
+}
+
+func TestHGetEx(t *testing.T) {
+	r.Del("key")
+	r.HSet("key", "field", "value")
+	if values, err := r.HGetEx("key", &HGetExArgs{EX: 100}, "field"); err != nil {
+		t.Error(err)
+	} else if len(values) != 1 || string(values[0]) != "value" {
+		t.Fail()
+	}
+	if ttl, _ := r.HTTL("key", "field"); len(ttl) != 1 || ttl[0] <= 0 {
+		t.Fail()
+	}
+	r.HGetEx("key", &HGetExArgs{Persist: true}, "field")
+	if ttl, _ := r.HTTL("key", "field"); len(ttl) != 1 || ttl[0] != -1 {
+		t.Fail()
+	}
+}
+
+func TestHSetEx(t *testing.T) {
+	r.Del("key")
+	if b, err := r.HSetEx("key", &HSetExArgs{FNX: true, PX: 100000}, map[string]string{"a": "1", "b": "2"}); err != nil {
+		t.Error(err)
+	} else if !b {
+		t.Fail()
+	}
+	if b, err := r.HSetEx("key", &HSetExArgs{FNX: true}, map[string]string{"a": "3"}); err != nil {
+		t.Error(err)
+	} else if b {
+		t.Fail()
+	}
+	if b, _ := r.HSetEx("key", &HSetExArgs{FXX: true, KeepTTL: true}, map[string]string{"a": "3"}); !b {
+		t.Fail()
+	}
+	if ttl, _ := r.HTTL("key", "a"); len(ttl) != 1 || ttl[0] <= 0 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,333 @@
+package goredis
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InfoMap returns the fields of the INFO sections by section and name, like info["memory"]["used_memory"].
+// The section names are lower case. Without sections the default ones are returned,
+// "all" and "everything" return more, like commandstats and errorstats.
+// Several sections at once need Redis 7.0.
+func (r *Redis) InfoMap(sections ...string) (map[string]map[string]string, error) {
+	args := packArgs("INFO", sections)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	info, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	return infoSections(info), nil
+}
+
+// infoSections parses an INFO reply by section, the fields before any section header are in "".
+func infoSections(info string) map[string]map[string]string {
+	sections := make(map[string]map[string]string)
+	fields := make(map[string]string)
+	sections[""] = fields
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line[0] == '#' {
+			name := strings.ToLower(strings.TrimSpace(line[1:]))
+			if fields = sections[name]; fields == nil {
+				fields = make(map[string]string)
+				sections[name] = fields
+			}
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 {
+			fields[line[:i]] = line[i+1:]
+		}
+	}
+	if len(sections[""]) == 0 {
+		delete(sections, "")
+	}
+	return sections
+}
+
+// ServerInfo is the typed reply of INFO, a section is nil if it was not returned.
+// The fields missing from a section, like those of a newer server version, are zero.
+// Sections holds all the fields by section, like InfoMap.
+type ServerInfo struct {
+	Server       *InfoServer
+	Clients      *InfoClients
+	Memory       *InfoMemory
+	Persistence  *InfoPersistence
+	Stats        *InfoStats
+	Replication  *InfoReplication
+	CPU          *InfoCPU
+	Keyspace     map[int]InfoKeyspace
+	Commandstats map[string]InfoCommandStats
+	Errorstats   map[string]int64
+	Sections     map[string]map[string]string
+}
+
+// InfoServer is the server section of INFO.
+type InfoServer struct {
+	RedisVersion    string `info:"redis_version"`
+	RedisMode       string `info:"redis_mode"`
+	OS              string `info:"os"`
+	ArchBits        int64  `info:"arch_bits"`
+	ProcessID       int64  `info:"process_id"`
+	RunID           string `info:"run_id"`
+	TCPPort         int64  `info:"tcp_port"`
+	UptimeInSeconds int64  `info:"uptime_in_seconds"`
+	Hz              int64  `info:"hz"`
+	ExecutablePath  string `info:"executable"`
+	ConfigFile      string `info:"config_file"`
+}
+
+// InfoClients is the clients section of INFO.
+type InfoClients struct {
+	ConnectedClients            int64 `info:"connected_clients"`
+	ClusterConnections          int64 `info:"cluster_connections"`
+	MaxClients                  int64 `info:"maxclients"`
+	ClientRecentMaxInputBuffer  int64 `info:"client_recent_max_input_buffer"`
+	ClientRecentMaxOutputBuffer int64 `info:"client_recent_max_output_buffer"`
+	BlockedClients              int64 `info:"blocked_clients"`
+	TrackingClients             int64 `info:"tracking_clients"`
+	PubsubClients               int64 `info:"pubsub_clients"`
+}
+
+// InfoMemory is the memory section of INFO, sizes are in bytes.
+type InfoMemory struct {
+	UsedMemory            int64   `info:"used_memory"`
+	UsedMemoryRSS         int64   `info:"used_memory_rss"`
+	UsedMemoryPeak        int64   `info:"used_memory_peak"`
+	UsedMemoryDataset     int64   `info:"used_memory_dataset"`
+	UsedMemoryLua         int64   `info:"used_memory_lua"`
+	MaxMemory             int64   `info:"maxmemory"`
+	MaxMemoryPolicy       string  `info:"maxmemory_policy"`
+	MemFragmentationRatio float64 `info:"mem_fragmentation_ratio"`
+	MemAllocator          string  `info:"mem_allocator"`
+}
+
+// InfoPersistence is the persistence section of INFO, times are Unix timestamps.
+type InfoPersistence struct {
+	Loading                 bool   `info:"loading"`
+	RDBChangesSinceLastSave int64  `info:"rdb_changes_since_last_save"`
+	RDBBgsaveInProgress     bool   `info:"rdb_bgsave_in_progress"`
+	RDBLastSaveTime         int64  `info:"rdb_last_save_time"`
+	RDBLastBgsaveStatus     string `info:"rdb_last_bgsave_status"`
+	AOFEnabled              bool   `info:"aof_enabled"`
+	AOFRewriteInProgress    bool   `info:"aof_rewrite_in_progress"`
+	AOFLastBgrewriteStatus  string `info:"aof_last_bgrewrite_status"`
+	AOFLastWriteStatus      string `info:"aof_last_write_status"`
+}
+
+// InfoStats is the stats section of INFO.
+type InfoStats struct {
+	TotalConnectionsReceived int64   `info:"total_connections_received"`
+	TotalCommandsProcessed   int64   `info:"total_commands_processed"`
+	InstantaneousOpsPerSec   int64   `info:"instantaneous_ops_per_sec"`
+	TotalNetInputBytes       int64   `info:"total_net_input_bytes"`
+	TotalNetOutputBytes      int64   `info:"total_net_output_bytes"`
+	InstantaneousInputKbps   float64 `info:"instantaneous_input_kbps"`
+	InstantaneousOutputKbps  float64 `info:"instantaneous_output_kbps"`
+	RejectedConnections      int64   `info:"rejected_connections"`
+	SyncFull                 int64   `info:"sync_full"`
+	SyncPartialOK            int64   `info:"sync_partial_ok"`
+	SyncPartialErr           int64   `info:"sync_partial_err"`
+	ExpiredKeys              int64   `info:"expired_keys"`
+	EvictedKeys              int64   `info:"evicted_keys"`
+	KeyspaceHits             int64   `info:"keyspace_hits"`
+	KeyspaceMisses           int64   `info:"keyspace_misses"`
+	PubsubChannels           int64   `info:"pubsub_channels"`
+	PubsubPatterns           int64   `info:"pubsub_patterns"`
+	TotalErrorReplies        int64   `info:"total_error_replies"`
+}
+
+// InfoReplication is the replication section of INFO.
+// The Master fields are only set on replicas, Replicas only on masters.
+type InfoReplication struct {
+	Role                   string `info:"role"`
+	ConnectedReplicas      int64  `info:"connected_slaves"`
+	Replicas               []InfoReplica
+	MasterHost             string `info:"master_host"`
+	MasterPort             int64  `info:"master_port"`
+	MasterLinkStatus       string `info:"master_link_status"`
+	MasterLastIOSecondsAgo int64  `info:"master_last_io_seconds_ago"`
+	MasterSyncInProgress   bool   `info:"master_sync_in_progress"`
+	ReplicaReplOffset      int64  `info:"slave_repl_offset"`
+	ReplicaPriority        int64  `info:"slave_priority"`
+	ReplicaReadOnly        bool   `info:"slave_read_only"`
+	MasterReplID           string `info:"master_replid"`
+	MasterReplOffset       int64  `info:"master_repl_offset"`
+	ReplBacklogActive      bool   `info:"repl_backlog_active"`
+	ReplBacklogSize        int64  `info:"repl_backlog_size"`
+}
+
+// InfoReplica is a replica connected to a master, a slaveN line of the replication section.
+type InfoReplica struct {
+	IP     string `info:"ip"`
+	Port   int64  `info:"port"`
+	State  string `info:"state"`
+	Offset int64  `info:"offset"`
+	Lag    int64  `info:"lag"`
+}
+
+// InfoCPU is the cpu section of INFO, in seconds.
+type InfoCPU struct {
+	UsedCPUSys          float64 `info:"used_cpu_sys"`
+	UsedCPUUser         float64 `info:"used_cpu_user"`
+	UsedCPUSysChildren  float64 `info:"used_cpu_sys_children"`
+	UsedCPUUserChildren float64 `info:"used_cpu_user_children"`
+}
+
+// InfoKeyspace is a database of the keyspace section of INFO, AvgTTL is in milliseconds.
+type InfoKeyspace struct {
+	Keys    int64 `info:"keys"`
+	Expires int64 `info:"expires"`
+	AvgTTL  int64 `info:"avg_ttl"`
+}
+
+// InfoCommandStats is a command of the commandstats section of INFO, Usec are microseconds.
+type InfoCommandStats struct {
+	Calls         int64   `info:"calls"`
+	Usec          int64   `info:"usec"`
+	UsecPerCall   float64 `info:"usec_per_call"`
+	RejectedCalls int64   `info:"rejected_calls"`
+	FailedCalls   int64   `info:"failed_calls"`
+}
+
+// ServerInfo returns the typed INFO sections, see InfoMap for sections.
+func (r *Redis) ServerInfo(sections ...string) (*ServerInfo, error) {
+	all, err := r.InfoMap(sections...)
+	if err != nil {
+		return nil, err
+	}
+	return serverInfoValue(all), nil
+}
+
+func serverInfoValue(all map[string]map[string]string) *ServerInfo {
+	info := &ServerInfo{Sections: all}
+	if fields, ok := all["server"]; ok {
+		info.Server = &InfoServer{}
+		infoFill(info.Server, fields)
+	}
+	if fields, ok := all["clients"]; ok {
+		info.Clients = &InfoClients{}
+		infoFill(info.Clients, fields)
+	}
+	if fields, ok := all["memory"]; ok {
+		info.Memory = &InfoMemory{}
+		infoFill(info.Memory, fields)
+	}
+	if fields, ok := all["persistence"]; ok {
+		info.Persistence = &InfoPersistence{}
+		infoFill(info.Persistence, fields)
+	}
+	if fields, ok := all["stats"]; ok {
+		info.Stats = &InfoStats{}
+		infoFill(info.Stats, fields)
+	}
+	if fields, ok := all["replication"]; ok {
+		info.Replication = &InfoReplication{}
+		infoFill(info.Replication, fields)
+		for i := 0; ; i++ {
+			line, ok := fields["slave"+strconv.Itoa(i)]
+			if !ok {
+				break
+			}
+			var replica InfoReplica
+			infoFill(&replica, infoValues(line))
+			info.Replication.Replicas = append(info.Replication.Replicas, replica)
+		}
+	}
+	if fields, ok := all["cpu"]; ok {
+		info.CPU = &InfoCPU{}
+		infoFill(info.CPU, fields)
+	}
+	if fields, ok := all["keyspace"]; ok {
+		info.Keyspace = make(map[int]InfoKeyspace)
+		for name, line := range fields {
+			db, err := strconv.Atoi(strings.TrimPrefix(name, "db"))
+			if err != nil {
+				continue
+			}
+			var keyspace InfoKeyspace
+			infoFill(&keyspace, infoValues(line))
+			info.Keyspace[db] = keyspace
+		}
+	}
+	if fields, ok := all["commandstats"]; ok {
+		info.Commandstats = make(map[string]InfoCommandStats)
+		for name, line := range fields {
+			var stats InfoCommandStats
+			infoFill(&stats, infoValues(line))
+			info.Commandstats[strings.TrimPrefix(name, "cmdstat_")] = stats
+		}
+	}
+	if fields, ok := all["errorstats"]; ok {
+		info.Errorstats = make(map[string]int64)
+		for name, line := range fields {
+			count, _ := strconv.ParseInt(infoValues(line)["count"], 10, 64)
+			info.Errorstats[strings.TrimPrefix(name, "errorstat_")] = count
+		}
+	}
+	return info
+}
+
+// infoValues parses the k1=v1,k2=v2 values of the keyspace, replica and stats lines.
+func infoValues(line string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(line, ",") {
+		if i := strings.IndexByte(pair, '='); i > 0 {
+			values[pair[:i]] = pair[i+1:]
+		}
+	}
+	return values
+}
+
+// infoFill sets the fields of the struct pointed by v from their info tag.
+func infoFill(v interface{}, fields map[string]string) {
+	fillTagged(v, "info", fields)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// fillTagged sets the fields of the struct pointed by v from the values named by their tag,
+// the values which are missing or do not parse are left zero.
+// A time.Duration value is a number of seconds.
+func fillTagged(v interface{}, tag string, fields map[string]string) {
+	s := reflect.ValueOf(v).Elem()
+	t := s.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get(tag)
+		if name == "" {
+			continue
+		}
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		field := s.Field(i)
+		if field.Type() == durationType {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				field.SetInt(int64(time.Duration(n) * time.Second))
+			}
+			continue
+		}
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int64:
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				field.SetInt(n)
+			}
+		case reflect.Float64:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				field.SetFloat(f)
+			}
+		case reflect.Bool:
+			field.SetBool(value == "1" || value == "yes")
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package goredis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testInfo = "# Server\r\nredis_version:7.2.4\r\nredis_mode:standalone\r\nprocess_id:42\r\nuptime_in_seconds:3600\r\n\r\n" +
+	"# Clients\r\nconnected_clients:12\r\nblocked_clients:1\r\n\r\n" +
+	"# Memory\r\nused_memory:1048576\r\nmaxmemory_policy:allkeys-lru\r\nmem_fragmentation_ratio:1.25\r\n\r\n" +
+	"# Persistence\r\nloading:0\r\naof_enabled:1\r\nrdb_last_bgsave_status:ok\r\n\r\n" +
+	"# Stats\r\nkeyspace_hits:100\r\nkeyspace_misses:5\r\ninstantaneous_input_kbps:0.52\r\n\r\n" +
+	"# Replication\r\nrole:master\r\nconnected_slaves:2\r\n" +
+	"slave0:ip=10.0.0.2,port=6379,state=online,offset=1234,lag=0\r\n" +
+	"slave1:ip=10.0.0.3,port=6380,state=wait_bgsave,offset=0,lag=1\r\n" +
+	"master_repl_offset:1234\r\n\r\n" +
+	"# CPU\r\nused_cpu_sys:1.50\r\nused_cpu_user:2.25\r\n\r\n" +
+	"# Commandstats\r\ncmdstat_get:calls=10,usec=20,usec_per_call=2.00,rejected_calls=1,failed_calls=0\r\n\r\n" +
+	"# Errorstats\r\nerrorstat_ERR:count=3\r\nerrorstat_WRONGTYPE:count=1\r\n\r\n" +
+	"# Keyspace\r\ndb0:keys=10,expires=2,avg_ttl=5000\r\ndb3:keys=1,expires=0,avg_ttl=0\r\n"
+
+func TestServerInfo(t *testing.T) {
+	var got []string
+	s := newFakeServer(t, func(args []string) interface{} {
+		got = args
+		if args[0] != "INFO" {
+			return errors.New("ERR unknown command")
+		}
+		return testInfo
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+
+	all, err := client.InfoMap("server", "memory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "INFO server memory" {
+		t.Error(got)
+	}
+	if all["memory"]["used_memory"] != "1048576" || all["server"]["redis_mode"] != "standalone" || len(all) != 10 {
+		t.Error(all)
+	}
+	info, err := client.ServerInfo("everything")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Server.RedisVersion != "7.2.4" || info.Server.ProcessID != 42 || info.Server.UptimeInSeconds != 3600 {
+		t.Error(info.Server)
+	}
+	if info.Clients.ConnectedClients != 12 || info.Clients.BlockedClients != 1 {
+		t.Error(info.Clients)
+	}
+	if info.Memory.UsedMemory != 1048576 || info.Memory.MaxMemoryPolicy != "allkeys-lru" || info.Memory.MemFragmentationRatio != 1.25 {
+		t.Error(info.Memory)
+	}
+	if info.Persistence.Loading || !info.Persistence.AOFEnabled || info.Persistence.RDBLastBgsaveStatus != "ok" {
+		t.Error(info.Persistence)
+	}
+	if info.Stats.KeyspaceHits != 100 || info.Stats.InstantaneousInputKbps != 0.52 {
+		t.Error(info.Stats)
+	}
+	rep := info.Replication
+	if rep.Role != "master" || rep.ConnectedReplicas != 2 || rep.MasterReplOffset != 1234 || len(rep.Replicas) != 2 {
+		t.Fatal(rep)
+	}
+	if rep.Replicas[1] != (InfoReplica{"10.0.0.3", 6380, "wait_bgsave", 0, 1}) {
+		t.Error(rep.Replicas[1])
+	}
+	if info.CPU.UsedCPUUser != 2.25 {
+		t.Error(info.CPU)
+	}
+	if info.Keyspace[0] != (InfoKeyspace{10, 2, 5000}) || info.Keyspace[3].Keys != 1 || len(info.Keyspace) != 2 {
+		t.Error(info.Keyspace)
+	}
+	if info.Commandstats["get"] != (InfoCommandStats{10, 20, 2, 1, 0}) {
+		t.Error(info.Commandstats)
+	}
+	if info.Errorstats["ERR"] != 3 || info.Errorstats["WRONGTYPE"] != 1 {
+		t.Error(info.Errorstats)
+	}
+}
+
+func TestServerInfoSections(t *testing.T) {
+	info := serverInfoValue(infoSections("# Memory\nused_memory:10\n"))
+	if info.Server != nil || info.Replication != nil || info.Keyspace != nil || info.Memory.UsedMemory != 10 {
+		t.Error(info)
+	}
+}
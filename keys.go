@@ -5,6 +5,26 @@ import (
 	"strconv"
 )
 
+// Copy copies the value stored at source to destination,
+// in the database db, or in the current database if db is negative.
+// With replace an existing destination is overwritten.
+// True if source was copied.
+// Available since Redis 6.2.
+func (r *Redis) Copy(source, destination string, db int, replace bool) (bool, error) {
+	args := packArgs("COPY", source, destination)
+	if db >= 0 {
+		args = append(args, "DB", db)
+	}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
 // Del removes the specified keys.
 // A key is ignored if it does not exist.
 // Integer reply: The number of keys that were removed.
@@ -48,6 +68,31 @@ func (r *Redis) Expire(key string, seconds int) (bool, error) {
 	return rp.BoolValue()
 }
 
+// Conditions of ExpireIf, ExpireAtIf, PExpireIf and PExpireAtIf.
+const (
+	ExpireNX = "NX"
+	ExpireXX = "XX"
+	ExpireGT = "GT"
+	ExpireLT = "LT"
+)
+
+// ExpireIf is Expire with a condition, ExpireNX, ExpireXX, ExpireGT or ExpireLT, or none if empty:
+// NX sets the timeout only if key has none, XX only if it has one,
+// GT only if the new timeout is greater than the current one, and LT only if it is less.
+// False if key does not exist or the condition was not met.
+// Available since Redis 7.0.
+func (r *Redis) ExpireIf(key string, seconds int, condition string) (bool, error) {
+	args := packArgs("EXPIRE", key, seconds)
+	if condition != "" {
+		args = append(args, condition)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
 // ExpireAt has the same effect and semantic as expire,
 // but instead of specifying the number of seconds representing the TTL (time to live),
 // it takes an absolute Unix timestamp (seconds since January 1, 1970).
@@ -59,7 +104,34 @@ func (r *Redis) ExpireAt(key string, timestamp int64) (bool, error) {
 	return rp.BoolValue()
 }
 
+// ExpireAtIf is ExpireAt with a condition, see ExpireIf.
+// Available since Redis 7.0.
+func (r *Redis) ExpireAtIf(key string, timestamp int64, condition string) (bool, error) {
+	args := packArgs("EXPIREAT", key, timestamp)
+	if condition != "" {
+		args = append(args, condition)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// ExpireTime returns the absolute Unix timestamp in seconds at which key will expire.
+// Integer reply: the timestamp, -1 if key has no timeout, -2 if key does not exist.
+// Available since Redis 7.0.
+func (r *Redis) ExpireTime(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("EXPIRETIME", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // Keys returns all keys matching pattern.
+// KEYS blocks the server while it walks the whole keyspace,
+// prefer ScanIterator, or DelMatching and the like for bulk changes, on production servers.
 func (r *Redis) Keys(pattern string) ([]string, error) {
 	rp, err := r.ExecuteCommand("KEYS", pattern)
 	if err != nil {
@@ -68,7 +140,20 @@ func (r *Redis) Keys(pattern string) ([]string, error) {
 	return rp.ListValue()
 }
 
-// Atomically transfer a key from a source Redis instance to a destination Redis instance.
+// MigrateArgs is the options of MIGRATE.
+// Copy does not remove the keys from the local instance,
+// Replace replaces existing keys on the remote instance.
+// Password authenticates with the remote instance, with Username too since Redis 6.0 (AUTH2).
+// Keys migrates several keys at once (Redis 3.0.6), the key argument of Migrate must then be empty.
+type MigrateArgs struct {
+	Copy     bool
+	Replace  bool
+	Username string
+	Password string
+	Keys     []string
+}
+
+// Migrate atomically transfers a key from a source Redis instance to a destination Redis instance.
 // On success the key is deleted from the original instance and is guaranteed to exist in the target instance.
 //
 // The command is atomic and blocks the two instances for the time required to transfer the key,
@@ -76,28 +161,138 @@ func (r *Redis) Keys(pattern string) ([]string, error) {
 // unless a timeout error occurs.
 //
 // The timeout specifies the maximum idle time in any moment of the communication
-// with the destination instance in milliseconds.
-//
-// COPY -- Do not remove the key from the local instance.
-// REPLACE -- Replace existing key on the remote instance.
-//
-// Status code reply: The command returns OK on success.
-// MIGRATE host port key destination-db timeout [COPY] [REPLACE]
+// with the destination instance in milliseconds. args may be nil.
 //
-// func (r *Redis) Migrate(host, port, key string, db, timeout int, cp, replace bool) error {
-// 	args := packArgs("MIGRATE", host, port, key, db, timeout)
-// 	if cp {
-// 		args = append(args, "COPY")
-// 	}
-// 	if replace {
-// 		args = append(args, "REPLACE")
-// 	}
-// 	rp, err := r.ExecuteCommand(args...)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	return rp.OKValue()
-// }
+// False if no key was found in the source instance (NOKEY).
+// MIGRATE host port key|"" destination-db timeout [COPY] [REPLACE] [AUTH password | AUTH2 username password] [KEYS key [key ...]]
+func (r *Redis) Migrate(host, port, key string, db, timeout int, args *MigrateArgs) (bool, error) {
+	cmds := packArgs("MIGRATE", host, port, key, db, timeout)
+	if args != nil {
+		if args.Copy {
+			cmds = append(cmds, "COPY")
+		}
+		if args.Replace {
+			cmds = append(cmds, "REPLACE")
+		}
+		if args.Username != "" {
+			cmds = append(cmds, "AUTH2", args.Username, args.Password)
+		} else if args.Password != "" {
+			cmds = append(cmds, "AUTH", args.Password)
+		}
+		if len(args.Keys) > 0 {
+			cmds = packArgs(cmds, "KEYS", args.Keys)
+		}
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return false, err
+	}
+	status, err := rp.StatusValue()
+	if err != nil {
+		return false, err
+	}
+	if status == "NOKEY" {
+		return false, nil
+	}
+	return true, rp.OKValue()
+}
+
+// MigrateKeyRetries is the number of times MigrateKeys moves a key
+// which was written during its move before giving up on it.
+const MigrateKeyRetries = 3
+
+// MigrateKeys moves the keys of src matching pattern to dst, with DUMP and RESTORE,
+// for servers where MIGRATE is not allowed or the instances can not reach each other.
+// The time to live of the keys is preserved, existing keys of dst are replaced.
+// A key is watched from its DUMP to its DEL, which is aborted if the key was written meanwhile,
+// the key is then moved again, up to MigrateKeyRetries times, else it is an error.
+// Unlike MIGRATE the move is not atomic and the writes to a key already moved land on src,
+// so the writers to the matched keys must be stopped while it runs.
+// It returns the number of keys moved.
+func MigrateKeys(src, dst *Redis, pattern string) (int64, error) {
+	s, err := src.Session()
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+	var moved int64
+	cursor := uint64(0)
+	for {
+		next, keys, err := s.Scan(cursor, pattern, 100)
+		if err != nil {
+			return moved, err
+		}
+		for _, key := range keys {
+			ok, err := migrateKey(s, dst, key)
+			if err != nil {
+				return moved, err
+			}
+			if ok {
+				moved++
+			}
+		}
+		if next == 0 {
+			return moved, nil
+		}
+		cursor = next
+	}
+}
+
+// migrateKey moves key from src to dst, false if the key disappeared meanwhile.
+// DUMP and PTTL are read while the key is watched, and the DEL is done in a MULTI/EXEC,
+// so the key is only deleted if the value and time to live restored on dst are still its current ones.
+func migrateKey(src *Session, dst *Redis, key string) (bool, error) {
+	restored := false
+	for i := 0; i < MigrateKeyRetries; i++ {
+		if _, err := src.ExecuteCommand("WATCH", key); err != nil {
+			return false, err
+		}
+		serialized, err := src.Dump(key)
+		if err != nil {
+			return false, err
+		}
+		ttl, err := src.PTTL(key)
+		if err != nil {
+			return false, err
+		}
+		if serialized == nil || ttl == -2 {
+			if _, err := src.ExecuteCommand("UNWATCH"); err != nil {
+				return false, err
+			}
+			if restored {
+				// The key was deleted from src since a previous try restored it.
+				_, err = dst.Del(key)
+			}
+			return false, err
+		}
+		if ttl < 0 {
+			ttl = 0
+		}
+		if err := dst.RestoreWithArgs(key, int(ttl), serialized, &RestoreArgs{Replace: true}); err != nil {
+			return false, err
+		}
+		restored = true
+		if _, err := src.ExecuteCommand("MULTI"); err != nil {
+			return false, err
+		}
+		if _, err := src.ExecuteCommand("DEL", key); err != nil {
+			return false, err
+		}
+		rp, err := src.ExecuteCommand("EXEC")
+		if err != nil {
+			return false, err
+		}
+		replies, err := rp.MultiValue()
+		if err != nil {
+			return false, err
+		}
+		if replies != nil {
+			return true, nil
+		}
+		// EXEC aborted, the key was written since WATCH.
+	}
+	return false, errors.New("migrate keys: " + key + " kept changing during its move")
+}
 
 // Move moves key from the currently selected database (see SELECT)
 // to the specified destination database.
@@ -121,6 +316,45 @@ func (r *Redis) Object(subcommand string, arguments ...string) (*Reply, error) {
 	return r.ExecuteCommand(args...)
 }
 
+// ObjectEncoding returns the internal encoding of the value stored at key,
+// or an empty string when key does not exist.
+func (r *Redis) ObjectEncoding(key string) (string, error) {
+	rp, err := r.ExecuteCommand("OBJECT", "ENCODING", key)
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// ObjectFreq returns the logarithmic access frequency counter of key,
+// only available when maxmemory-policy is an LFU policy.
+func (r *Redis) ObjectFreq(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("OBJECT", "FREQ", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ObjectIdleTime returns the number of seconds since key was last accessed,
+// not available when maxmemory-policy is an LFU policy.
+func (r *Redis) ObjectIdleTime(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("OBJECT", "IDLETIME", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ObjectRefCount returns the number of references of the value stored at key.
+func (r *Redis) ObjectRefCount(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("OBJECT", "REFCOUNT", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // Persist removes the existing timeout on key,
 // turning the key from volatile (a key with an expire set) to persistent
 // (a key that will never expire as no timeout is associated).
@@ -144,6 +378,20 @@ func (r *Redis) PExpire(key string, milliseconds int) (bool, error) {
 	return rp.BoolValue()
 }
 
+// PExpireIf is PExpire with a condition, see ExpireIf.
+// Available since Redis 7.0.
+func (r *Redis) PExpireIf(key string, milliseconds int, condition string) (bool, error) {
+	args := packArgs("PEXPIRE", key, milliseconds)
+	if condition != "" {
+		args = append(args, condition)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
 // PExpireAt has the same effect and semantic as EXPIREAT,
 // but the Unix time at which the key will expire is specified in milliseconds instead of seconds.
 func (r *Redis) PExpireAt(key string, timestamp int64) (bool, error) {
@@ -154,6 +402,30 @@ func (r *Redis) PExpireAt(key string, timestamp int64) (bool, error) {
 	return rp.BoolValue()
 }
 
+// PExpireAtIf is PExpireAt with a condition, see ExpireIf.
+// Available since Redis 7.0.
+func (r *Redis) PExpireAtIf(key string, timestamp int64, condition string) (bool, error) {
+	args := packArgs("PEXPIREAT", key, timestamp)
+	if condition != "" {
+		args = append(args, condition)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// PExpireTime is ExpireTime in milliseconds.
+// Available since Redis 7.0.
+func (r *Redis) PExpireTime(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("PEXPIRETIME", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // PTTL returns the remaining time to live of a key that has an expire set,
 // with the sole difference that TTL returns the amount of remaining time in seconds
 // while PTTL returns it in milliseconds.
@@ -210,6 +482,53 @@ func (r *Redis) Restore(key string, ttl int, serialized string) error {
 	return rp.OKValue()
 }
 
+// RestoreArgs is the options of RESTORE.
+// Replace overwrites an existing key, AbsTTL makes ttl an absolute Unix timestamp in milliseconds.
+// IdleTime (seconds) and Freq set the eviction information of the key, Freq is only sent if not 0.
+// The options are available since Redis 3.0 (REPLACE) and 5.0 (the others).
+type RestoreArgs struct {
+	Replace  bool
+	AbsTTL   bool
+	IdleTime int
+	Freq     int
+}
+
+// RestoreWithArgs is Restore with options and a binary serialized value, as returned by Dump. args may be nil.
+func (r *Redis) RestoreWithArgs(key string, ttl int, serialized []byte, args *RestoreArgs) error {
+	cmds := []interface{}{"RESTORE", key, ttl, serialized}
+	if args != nil {
+		if args.Replace {
+			cmds = append(cmds, "REPLACE")
+		}
+		if args.AbsTTL {
+			cmds = append(cmds, "ABSTTL")
+		}
+		if args.IdleTime > 0 {
+			cmds = append(cmds, "IDLETIME", args.IdleTime)
+		}
+		if args.Freq > 0 {
+			cmds = append(cmds, "FREQ", args.Freq)
+		}
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// Touch alters the last access time of keys.
+// Integer reply: the number of keys that exist.
+// Available since Redis 3.2.1.
+func (r *Redis) Touch(keys ...string) (int64, error) {
+	args := packArgs("TOUCH", keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // TTL returns the remaining time to live of a key that has a timeout.
 // Integer reply: TTL in seconds, or a negative value in order to signal an error (see the description above).
 func (r *Redis) TTL(key string) (int64, error) {
@@ -231,9 +550,28 @@ func (r *Redis) Type(key string) (string, error) {
 	return rp.StatusValue()
 }
 
+// Unlink removes keys like Del, but reclaims their memory in a background thread,
+// so it does not block on big values.
+// Integer reply: the number of keys that were unlinked.
+// Available since Redis 4.0.
+func (r *Redis) Unlink(keys ...string) (int64, error) {
+	args := packArgs("UNLINK", keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // Scan command:
 // SCAN cursor [MATCH pattern] [COUNT count]
 func (r *Redis) Scan(cursor uint64, pattern string, count int) (uint64, []string, error) {
+	return r.ScanType(cursor, pattern, count, "")
+}
+
+// ScanType is Scan returning only the keys of type typ, like string or zset, if typ is not empty.
+// TYPE is available since Redis 6.0.
+func (r *Redis) ScanType(cursor uint64, pattern string, count int, typ string) (uint64, []string, error) {
 	args := packArgs("SCAN", cursor)
 	if pattern != "" {
 		args = append(args, "MATCH", pattern)
@@ -241,14 +579,22 @@ func (r *Redis) Scan(cursor uint64, pattern string, count int) (uint64, []string
 	if count > 0 {
 		args = append(args, "COUNT", count)
 	}
+	if typ != "" {
+		args = append(args, "TYPE", typ)
+	}
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return 0, nil, err
 	}
+	return scanValue(rp)
+}
+
+// scanValue decodes the reply of the SCAN family, the next cursor and the elements of the batch.
+func scanValue(rp *Reply) (uint64, []string, error) {
 	if rp.Type == ErrorReply {
 		return 0, nil, errors.New(rp.Error)
 	}
-	if rp.Type != MultiReply {
+	if rp.Type != MultiReply || len(rp.Multi) != 2 {
 		return 0, nil, errors.New("scan protocol error")
 	}
 	first, err := rp.Multi[0].StringValue()
@@ -1,10 +1,33 @@
 package goredis
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
 
+func TestCopy(t *testing.T) {
+	r.Del("key", "key1")
+	r.Set("key", "value", 0, 0, false, false)
+	if b, err := r.Copy("key", "key1", -1, false); err != nil {
+		t.Error(err)
+	} else if !b {
+		t.Fail()
+	}
+	r.Set("key", "value2", 0, 0, false, false)
+	if b, _ := r.Copy("key", "key1", -1, false); b {
+		t.Fail()
+	}
+	if b, _ := r.Copy("key", "key1", -1, true); !b {
+		t.Fail()
+	}
+	if value, _ := r.Get("key1"); string(value) != "value2" {
+		t.Fail()
+	}
+}
+
 func TestDel(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	if n, err := r.Del("key"); err != nil {
@@ -50,6 +73,37 @@ func TestExpire(t *testing.T) {
 	}
 }
 
+func TestExpireIf(t *testing.T) {
+	r.Del("key")
+	r.Set("key", "value", 0, 0, false, false)
+	if b, err := r.ExpireIf("key", 100, ExpireXX); err != nil {
+		t.Error(err)
+	} else if b {
+		t.Fail()
+	}
+	if b, _ := r.ExpireIf("key", 100, ExpireNX); !b {
+		t.Fail()
+	}
+	if b, _ := r.PExpireIf("key", 50000, ExpireGT); b {
+		t.Fail()
+	}
+	if b, _ := r.PExpireIf("key", 50000, ExpireLT); !b {
+		t.Fail()
+	}
+	if b, _ := r.ExpireAtIf("key", 33177117420, ExpireLT); b {
+		t.Fail()
+	}
+	if b, _ := r.PExpireAtIf("key", 33177117420000, ExpireGT); !b {
+		t.Fail()
+	}
+	if b, err := r.ExpireIf("key", 100, ""); err != nil || !b {
+		t.Error(b, err)
+	}
+	if b, err := r.PExpireAtIf("key", 33177117420000, ""); err != nil || !b {
+		t.Error(b, err)
+	}
+}
+
 func TestExpireAt(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	if b, err := r.ExpireAt("key", time.Now().Add(10*time.Second).Unix()); err != nil {
@@ -64,6 +118,28 @@ func TestExpireAt(t *testing.T) {
 	}
 }
 
+func TestExpireTime(t *testing.T) {
+	r.Del("key")
+	if n, err := r.ExpireTime("key"); err != nil {
+		t.Error(err)
+	} else if n != -2 {
+		t.Fail()
+	}
+	r.Set("key", "value", 0, 0, false, false)
+	timestamp := time.Now().Unix() + 3600
+	r.ExpireAt("key", timestamp)
+	if n, err := r.ExpireTime("key"); err != nil {
+		t.Error(err)
+	} else if n != timestamp {
+		t.Fail()
+	}
+	if n, err := r.PExpireTime("key"); err != nil {
+		t.Error(err)
+	} else if n != timestamp*1000 {
+		t.Fail()
+	}
+}
+
 func TestKeys(t *testing.T) {
 	r.FlushDB()
 	keys, err := r.Keys("*")
@@ -110,6 +186,29 @@ func TestObject(t *testing.T) {
 	}
 }
 
+func TestObjectHelpers(t *testing.T) {
+	r.Del("key")
+	r.LPush("key", "hello world")
+	if encoding, err := r.ObjectEncoding("key"); err != nil {
+		t.Error(err)
+	} else if encoding == "" {
+		t.Fail()
+	}
+	if n, err := r.ObjectRefCount("key"); err != nil {
+		t.Error(err)
+	} else if n < 1 {
+		t.Fail()
+	}
+	if _, err := r.ObjectIdleTime("key"); err != nil {
+		t.Error(err)
+	}
+	if encoding, err := r.ObjectEncoding("nokey"); err != nil {
+		t.Error(err)
+	} else if encoding != "" {
+		t.Fail()
+	}
+}
+
 func TestPersist(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	r.Expire("key", 500)
@@ -204,6 +303,138 @@ func TestRestore(t *testing.T) {
 	}
 }
 
+func TestRestoreWithArgs(t *testing.T) {
+	r.Set("key", "value", 0, 0, false, false)
+	data, _ := r.Dump("key")
+	r.Set("key", "other", 0, 0, false, false)
+	if err := r.RestoreWithArgs("key", 0, data, nil); err == nil {
+		t.Error("restore over an existing key")
+	}
+	if err := r.RestoreWithArgs("key", 0, data, &RestoreArgs{Replace: true}); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := r.Get("key"); string(value) != "value" {
+		t.Error(string(value))
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	var got []string
+	s := newFakeServer(t, func(args []string) interface{} {
+		got = args
+		if args[3] == "missing" {
+			return fakeStatus("NOKEY")
+		}
+		return fakeStatus("OK")
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	ok, err := client.Migrate("10.0.0.1", "6379", "", 0, 1000, &MigrateArgs{Copy: true, Username: "user", Password: "pass", Keys: []string{"a", "b"}})
+	if err != nil || !ok {
+		t.Fatal(ok, err)
+	}
+	want := "MIGRATE 10.0.0.1 6379  0 1000 COPY AUTH2 user pass KEYS a b"
+	if strings.Join(got, " ") != want {
+		t.Error(got)
+	}
+	if ok, err := client.Migrate("10.0.0.1", "6379", "missing", 0, 1000, nil); err != nil || ok {
+		t.Error(ok, err)
+	}
+}
+
+func TestMigrateKeys(t *testing.T) {
+	dst, err := Dial(&DialConfig{network, address, 2, password, timeout, maxidle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.ClosePool()
+	r.Del("migrate:tenant:1", "migrate:tenant:2", "migrate:other")
+	dst.Del("migrate:tenant:1", "migrate:tenant:2")
+	defer r.Del("migrate:tenant:1", "migrate:tenant:2", "migrate:other")
+	defer dst.Del("migrate:tenant:1", "migrate:tenant:2")
+	r.Set("migrate:tenant:1", "one", 0, 0, false, false)
+	r.Set("migrate:tenant:2", "two", 100, 0, false, false)
+	r.Set("migrate:other", "value", 0, 0, false, false)
+	n, err := MigrateKeys(r, dst, "migrate:tenant:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatal(n)
+	}
+	if exists, _ := r.Exists("migrate:tenant:1"); exists {
+		t.Error("source key not deleted")
+	}
+	if exists, _ := r.Exists("migrate:other"); !exists {
+		t.Error("unmatched key moved")
+	}
+	if value, _ := dst.Get("migrate:tenant:2"); string(value) != "two" {
+		t.Error(string(value))
+	}
+	if ttl, _ := dst.TTL("migrate:tenant:2"); ttl <= 0 || ttl > 100 {
+		t.Error(ttl)
+	}
+	if ttl, _ := dst.TTL("migrate:tenant:1"); ttl != -1 {
+		t.Error(ttl)
+	}
+}
+
+func TestMigrateKeysWritten(t *testing.T) {
+	// A write to the key during its move, between its DUMP and its DEL, moves it again.
+	writes := 0
+	restores := 0
+	s := newFakeServer(t, func(args []string) interface{} {
+		if args[0] != "RESTORE" {
+			return errors.New("ERR unknown command")
+		}
+		restores++
+		if restores <= writes {
+			r.Set("migrate:written", "value"+strconv.Itoa(restores), 0, 0, false, false)
+		}
+		return fakeStatus("OK")
+	})
+	defer s.Close()
+	dst, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.ClosePool()
+	defer r.Del("migrate:written")
+
+	r.Set("migrate:written", "value", 0, 0, false, false)
+	writes = 1
+	if n, err := MigrateKeys(r, dst, "migrate:written"); err != nil || n != 1 || restores != 2 {
+		t.Fatal(n, err, restores)
+	}
+	if exists, _ := r.Exists("migrate:written"); exists {
+		t.Error("source key not deleted")
+	}
+
+	r.Set("migrate:written", "value", 0, 0, false, false)
+	restores = 0
+	writes = MigrateKeyRetries
+	if n, err := MigrateKeys(r, dst, "migrate:written"); err == nil || n != 0 {
+		t.Fatal(n, err)
+	}
+	if value, _ := r.Get("migrate:written"); string(value) != "value"+strconv.Itoa(MigrateKeyRetries) {
+		t.Error("written key deleted", string(value))
+	}
+}
+
+func TestTouch(t *testing.T) {
+	r.Del("key", "key1")
+	r.Set("key", "value", 0, 0, false, false)
+	if n, err := r.Touch("key", "key1"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
 func TestTTL(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	r.Expire("key", 100)
@@ -232,6 +463,19 @@ func TestType(t *testing.T) {
 	}
 }
 
+func TestUnlink(t *testing.T) {
+	r.Del("key", "key1")
+	r.Set("key", "value", 0, 0, false, false)
+	if n, err := r.Unlink("key", "key1"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+	if b, _ := r.Exists("key"); b {
+		t.Fail()
+	}
+}
+
 func TestScan(t *testing.T) {
 	r.FlushDB()
 	cursor, list, err := r.Scan(0, "", 0)
@@ -243,3 +487,24 @@ func TestScan(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestScanType(t *testing.T) {
+	r.FlushDB()
+	r.Set("key", "value", 0, 0, false, false)
+	r.LPush("list", "value")
+	var keys []string
+	for cursor := uint64(0); ; {
+		next, list, err := r.ScanType(cursor, "", 0, "list")
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, list...)
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	if len(keys) != 1 || keys[0] != "list" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,230 @@
+package goredis
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// LDBLine is a line of output of the Lua debugger.
+// Tag is the kind of line, like redis, reply, value or error for <redis>, <reply>, <value> and <error>,
+// empty for the lines without one, like the listing of the source.
+type LDBLine struct {
+	Tag  string
+	Text string
+}
+
+// LDBOutput is the output of a debugger command.
+// Line is the line the script stopped at, with the reason, like step over or break point,
+// 0 if the script did not stop.
+type LDBOutput struct {
+	Lines  []LDBLine
+	Line   int
+	Reason string
+}
+
+// LDBSession is a debugging session of a Lua script, on a dedicated connection.
+//
+//	s, out, err := r.DebugScript("local a = 1\nreturn a", nil, true)
+//	defer s.Close()
+//	for !s.Done() {
+//		if out, err = s.Step(); err != nil {
+//			break
+//		}
+//	}
+//	rp := s.Result()
+//
+// The session ends when the script ends or is aborted,
+// the server then closes the connection.
+type LDBSession struct {
+	conn   *connection
+	done   bool
+	result *Reply
+}
+
+// DebugScript starts debugging script, stopped before its first line, and returns its output.
+// With sync the server is blocked during the session and the changes of the script are kept,
+// else it runs the script in a forked process, not blocking other clients, whose changes are rolled back.
+// SCRIPT DEBUG YES|SYNC then EVAL script numkeys key [key ...] arg [arg ...]
+// Available since Redis 3.2.
+func (r *Redis) DebugScript(script string, keys []string, sync bool, args ...interface{}) (*LDBSession, *LDBOutput, error) {
+	c, err := r.pool.dial()
+	if err != nil {
+		return nil, nil, err
+	}
+	mode := "YES"
+	if sync {
+		mode = "SYNC"
+	}
+	if err := c.SendCommand("SCRIPT", "DEBUG", mode); err != nil {
+		c.Conn.Close()
+		return nil, nil, err
+	}
+	rp, err := c.RecvReply()
+	if err == nil {
+		err = rp.OKValue()
+	}
+	if err != nil {
+		c.Conn.Close()
+		return nil, nil, err
+	}
+	s := &LDBSession{conn: c}
+	out, err := s.Command(packArgs("EVAL", script, len(keys), keys, scriptArgs(args))...)
+	if err != nil {
+		s.Close()
+		return nil, nil, err
+	}
+	return s, out, nil
+}
+
+// Command sends a raw debugger command, like "list" or "maxlen 0", and returns its output.
+func (s *LDBSession) Command(args ...interface{}) (*LDBOutput, error) {
+	if s.done {
+		return nil, errors.New("ldb session ended")
+	}
+	if err := s.conn.SendCommand(args...); err != nil {
+		return nil, err
+	}
+	rp, err := s.conn.RecvReply()
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type != MultiReply {
+		// The script ended without output, this is the reply of EVAL.
+		s.end(rp)
+		return &LDBOutput{}, nil
+	}
+	out := &LDBOutput{}
+	for _, subrp := range rp.Multi {
+		text, err := subrp.StatusValue()
+		if err != nil {
+			// Not a debugger output, the script returned an array.
+			s.end(rp)
+			return &LDBOutput{}, nil
+		}
+		if text == "<endsession>" {
+			result, err := s.conn.RecvReply()
+			if err != nil {
+				return nil, err
+			}
+			s.end(result)
+			continue
+		}
+		out.Lines = append(out.Lines, ldbLine(text))
+		if strings.HasPrefix(text, "* Stopped at ") {
+			out.Line, out.Reason = ldbStopped(text)
+		}
+	}
+	return out, nil
+}
+
+func (s *LDBSession) end(result *Reply) {
+	s.done = true
+	s.result = result
+	s.conn.Conn.Close()
+}
+
+// Step runs the current line and stops at the next one.
+func (s *LDBSession) Step() (*LDBOutput, error) {
+	return s.Command("step")
+}
+
+// Continue runs the script until the next breakpoint or its end.
+func (s *LDBSession) Continue() (*LDBOutput, error) {
+	return s.Command("continue")
+}
+
+// Break adds a breakpoint at line, or removes it if line is negative.
+func (s *LDBSession) Break(line int) (*LDBOutput, error) {
+	return s.Command("break", line)
+}
+
+// Breakpoints lists the breakpoints.
+func (s *LDBSession) Breakpoints() (*LDBOutput, error) {
+	return s.Command("break")
+}
+
+// ClearBreakpoints removes all the breakpoints.
+func (s *LDBSession) ClearBreakpoints() (*LDBOutput, error) {
+	return s.Command("break", 0)
+}
+
+// List lists the source around the current line, all of it with Whole.
+func (s *LDBSession) List() (*LDBOutput, error) {
+	return s.Command("list")
+}
+
+// Whole lists all the source of the script.
+func (s *LDBSession) Whole() (*LDBOutput, error) {
+	return s.Command("whole")
+}
+
+// Print prints the local variables, or only the variable name if name is not empty.
+func (s *LDBSession) Print(name string) (*LDBOutput, error) {
+	if name == "" {
+		return s.Command("print")
+	}
+	return s.Command("print", name)
+}
+
+// Eval evaluates the Lua code in the context of the script, which variables it can not see.
+func (s *LDBSession) Eval(code string) (*LDBOutput, error) {
+	return s.Command("eval", code)
+}
+
+// Redis executes a redis command from the script, like redis.call would.
+func (s *LDBSession) Redis(args ...string) (*LDBOutput, error) {
+	return s.Command(packArgs("redis", args)...)
+}
+
+// Trace shows the backtrace of the script.
+func (s *LDBSession) Trace() (*LDBOutput, error) {
+	return s.Command("trace")
+}
+
+// Abort stops the script, ending the session, its Result is then an error.
+func (s *LDBSession) Abort() (*LDBOutput, error) {
+	return s.Command("abort")
+}
+
+// Done returns true once the script ended.
+func (s *LDBSession) Done() bool {
+	return s.done
+}
+
+// Result returns the reply of the script once Done, nil before.
+func (s *LDBSession) Result() *Reply {
+	return s.result
+}
+
+// Close ends the session, the connection is closed, aborting the script if it is still running.
+func (s *LDBSession) Close() {
+	if !s.done {
+		s.done = true
+		s.conn.Conn.Close()
+	}
+}
+
+// ldbLine splits the <tag> of text.
+func ldbLine(text string) LDBLine {
+	if strings.HasPrefix(text, "<") {
+		if i := strings.Index(text, ">"); i > 0 {
+			return LDBLine{Tag: text[1:i], Text: strings.TrimPrefix(text[i+1:], " ")}
+		}
+	}
+	return LDBLine{Text: text}
+}
+
+// ldbStopped parses "* Stopped at 4, stop reason = step over".
+func ldbStopped(text string) (int, string) {
+	text = strings.TrimPrefix(text, "* Stopped at ")
+	parts := strings.SplitN(text, ", stop reason = ", 2)
+	line, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ""
+	}
+	if len(parts) == 2 {
+		return line, parts[1]
+	}
+	return line, ""
+}
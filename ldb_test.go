@@ -0,0 +1,89 @@
+package goredis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDebugScript(t *testing.T) {
+	var commands []string
+	s := newFakeServer(t, func(args []string) interface{} {
+		commands = append(commands, strings.Join(args, " "))
+		switch args[0] {
+		case "SCRIPT":
+			return fakeStatus("OK")
+		case "EVAL":
+			return []interface{}{
+				fakeStatus("* Stopped at 1, stop reason = step over"),
+				fakeStatus("-> 1   local a = redis.call('GET', KEYS[1])"),
+			}
+		case "step":
+			return []interface{}{
+				fakeStatus("<redis> GET key"),
+				fakeStatus("<reply> \"value\""),
+				fakeStatus("* Stopped at 2, stop reason = step over"),
+				fakeStatus("-> 2   return a"),
+			}
+		case "print":
+			return []interface{}{fakeStatus("<value> a = \"value\"")}
+		case "continue":
+			return fakeReplies{
+				[]interface{}{fakeStatus("<endsession>")},
+				"value",
+			}
+		}
+		return errors.New("ERR unknown command")
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	// The session dials like the pool does, not client.address,
+	// as a FailoverClient dials its current master.
+	client.address = "127.0.0.1:1"
+	client.pool.Dial = func() (*connection, error) {
+		return client.dialAddress(s.Addr())
+	}
+
+	session, out, err := client.DebugScript("local a = redis.call('GET', KEYS[1])\nreturn a", []string{"key"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if commands[0] != "SCRIPT DEBUG SYNC" || !strings.HasPrefix(commands[1], "EVAL ") || !strings.HasSuffix(commands[1], " 1 key") {
+		t.Error(commands)
+	}
+	if out.Line != 1 || out.Reason != "step over" || len(out.Lines) != 2 {
+		t.Error(out)
+	}
+	if out, err = session.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if out.Line != 2 || out.Lines[0] != (LDBLine{"redis", "GET key"}) || out.Lines[1] != (LDBLine{"reply", "\"value\""}) {
+		t.Error(out)
+	}
+	if out, err = session.Print(""); err != nil {
+		t.Fatal(err)
+	}
+	if out.Line != 0 || len(out.Lines) != 1 || out.Lines[0].Tag != "value" {
+		t.Error(out)
+	}
+	if session.Done() || session.Result() != nil {
+		t.Fatal("session ended early")
+	}
+	if out, err = session.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Lines) != 0 || !session.Done() {
+		t.Error(out, session.Done())
+	}
+	if value, err := session.Result().StringValue(); err != nil || value != "value" {
+		t.Error(value, err)
+	}
+	if _, err := session.Step(); err == nil {
+		t.Error("step after the end of the session")
+	}
+}
@@ -1,5 +1,15 @@
 package goredis
 
+import (
+	"errors"
+)
+
+// List directions of LMove, BLMove, LMPop and BLMPop.
+const (
+	ListLeft  = "LEFT"
+	ListRight = "RIGHT"
+)
+
 // BLPop is a blocking list pop primitive.
 // It is the blocking version of LPOP
 // because it blocks the connection when there are no elements to pop from any of the given lists.
@@ -20,6 +30,34 @@ func (r *Redis) BLPop(keys []string, timeout int) ([]string, error) {
 	return rp.ListValue()
 }
 
+// BLMove is the blocking variant of LMove.
+// When source is empty, Redis will block the connection until
+// another client pushes to it or until timeout is reached.
+// A timeout of zero can be used to block indefinitely.
+// Bulk reply: the element being moved, or nil when the timeout is reached.
+// Available since Redis 6.2.
+func (r *Redis) BLMove(source, destination, whereFrom, whereTo string, timeout int) ([]byte, error) {
+	rp, err := r.ExecuteCommand("BLMOVE", source, destination, whereFrom, whereTo, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == MultiReply {
+		return nil, nil
+	}
+	return rp.BytesValue()
+}
+
+// BLMPop is the blocking variant of LMPop.
+// An empty key is returned when the timeout is reached.
+// Available since Redis 7.0.
+func (r *Redis) BLMPop(timeout int, where string, count int, keys ...string) (string, []string, error) {
+	args := packArgs("BLMPOP", timeout, len(keys), keys, where)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	return lmpopValue(r.ExecuteCommand(args...))
+}
+
 // BRPop pops elements from the tail of a list instead of popping from the head.
 func (r *Redis) BRPop(keys []string, timeout int) ([]string, error) {
 	args := packArgs("BRPOP", keys, timeout)
@@ -41,6 +79,8 @@ func (r *Redis) BRPop(keys []string, timeout int) ([]string, error) {
 // A timeout of zero can be used to block indefinitely.
 // Bulk reply: the element being popped from source and pushed to destination.
 // If timeout is reached, a Null multi-bulk reply is returned.
+// BRPOPLPUSH is deprecated since Redis 6.2, on newer servers use
+// BLMove(source, destination, ListRight, ListLeft, timeout) instead.
 func (r *Redis) BRPopLPush(source, destination string, timeout int) ([]byte, error) {
 	rp, err := r.ExecuteCommand("BRPOPLPUSH", source, destination, timeout)
 	if err != nil {
@@ -90,6 +130,53 @@ func (r *Redis) LLen(key string) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// LMove atomically returns and removes the first (whereFrom ListLeft) or last (whereFrom ListRight) element
+// of the list stored at source, and pushes it at the first (whereTo ListLeft) or last (whereTo ListRight) element
+// of the list stored at destination.
+// Bulk reply: the element being moved, or nil when source does not exist.
+// Available since Redis 6.2.
+func (r *Redis) LMove(source, destination, whereFrom, whereTo string) ([]byte, error) {
+	rp, err := r.ExecuteCommand("LMOVE", source, destination, whereFrom, whereTo)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
+// LMPop pops up to count elements, one if count is 0, from the head (where ListLeft) or the tail (where ListRight)
+// of the first non-empty list of keys.
+// It returns the key of the list and the popped elements, an empty key when every list is empty.
+// Available since Redis 7.0.
+func (r *Redis) LMPop(where string, count int, keys ...string) (string, []string, error) {
+	args := packArgs("LMPOP", len(keys), keys, where)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	return lmpopValue(r.ExecuteCommand(args...))
+}
+
+func lmpopValue(rp *Reply, err error) (string, []string, error) {
+	if err != nil {
+		return "", nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil || multi == nil {
+		return "", nil, err
+	}
+	if len(multi) != 2 {
+		return "", nil, errors.New("lmpop protocol error")
+	}
+	key, err := multi[0].StringValue()
+	if err != nil {
+		return "", nil, err
+	}
+	elements, err := multi[1].ListValue()
+	if err != nil {
+		return "", nil, err
+	}
+	return key, elements, nil
+}
+
 // LPop removes and returns the first element of the list stored at key.
 // Bulk reply: the value of the first element, or nil when key does not exist.
 func (r *Redis) LPop(key string) ([]byte, error) {
@@ -100,6 +187,77 @@ func (r *Redis) LPop(key string) ([]byte, error) {
 	return rp.BytesValue()
 }
 
+// LPopCount removes and returns up to count elements from the head of the list stored at key.
+// Multi-bulk reply: the popped elements, or nil when key does not exist.
+// Available since Redis 6.2.
+func (r *Redis) LPopCount(key string, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("LPOP", key, count)
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == BulkReply && rp.Bulk == nil {
+		return nil, nil
+	}
+	return rp.ListValue()
+}
+
+// LPosArgs is the options of LPOS.
+// Rank skips the first Rank-1 matches, a negative Rank searches from the tail.
+// MaxLen compares at most MaxLen elements, 0 meaning the whole list.
+type LPosArgs struct {
+	Rank   int
+	MaxLen int
+}
+
+func (a *LPosArgs) args() []interface{} {
+	var args []interface{}
+	if a == nil {
+		return args
+	}
+	if a.Rank != 0 {
+		args = append(args, "RANK", a.Rank)
+	}
+	if a.MaxLen > 0 {
+		args = append(args, "MAXLEN", a.MaxLen)
+	}
+	return args
+}
+
+// LPos returns the index of the first element equal to element in the list stored at key, args may be nil.
+// Integer reply: the index, or -1 when there is no match.
+// Available since Redis 6.0.6.
+func (r *Redis) LPos(key, element string, args *LPosArgs) (int64, error) {
+	cmds := packArgs("LPOS", key, element, args.args())
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	if rp.Type == BulkReply && rp.Bulk == nil {
+		return -1, nil
+	}
+	return rp.IntegerValue()
+}
+
+// LPosCount is LPos returning the indexes of the first count matches, all of them if count is 0.
+func (r *Redis) LPosCount(key, element string, count int, args *LPosArgs) ([]int64, error) {
+	cmds := packArgs("LPOS", key, element, args.args(), "COUNT", count)
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]int64, len(multi))
+	for i, subrp := range multi {
+		if indexes[i], err = subrp.IntegerValue(); err != nil {
+			return nil, err
+		}
+	}
+	return indexes, nil
+}
+
 // LPush insert all the specified values at the head of the list stored at key.
 // If key does not exist, it is created as empty list before performing the push operations.
 // When key holds a value that is not a list, an error is returned.
@@ -113,12 +271,14 @@ func (r *Redis) LPush(key string, values ...string) (int64, error) {
 	return rp.IntegerValue()
 }
 
-// LPushx inserts value at the head of the list stored at key,
+// LPushx inserts values at the head of the list stored at key,
 // only if key already exists and holds a list.
 // In contrary to LPUSH, no operation will be performed when key does not yet exist.
+// Multiple values need Redis 4.0.
 // Integer reply: the length of the list after the push operation.
-func (r *Redis) LPushx(key, value string) (int64, error) {
-	rp, err := r.ExecuteCommand("LPUSHX", key, value)
+func (r *Redis) LPushx(key string, values ...string) (int64, error) {
+	args := packArgs("LPUSHX", key, values)
+	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return 0, err
 	}
@@ -190,6 +350,20 @@ func (r *Redis) RPop(key string) ([]byte, error) {
 	return rp.BytesValue()
 }
 
+// RPopCount removes and returns up to count elements from the tail of the list stored at key.
+// Multi-bulk reply: the popped elements, or nil when key does not exist.
+// Available since Redis 6.2.
+func (r *Redis) RPopCount(key string, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("RPOP", key, count)
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == BulkReply && rp.Bulk == nil {
+		return nil, nil
+	}
+	return rp.ListValue()
+}
+
 // RPopLPush atomically returns and removes the last element (tail) of the list stored at source,
 // and pushes the element at the first element (head) of the list stored at destination.
 //
@@ -217,11 +391,13 @@ func (r *Redis) RPush(key string, values ...string) (int64, error) {
 	return rp.IntegerValue()
 }
 
-// RPushx inserts value at the tail of the list stored at key,
+// RPushx inserts values at the tail of the list stored at key,
 // only if key already exists and holds a list.
 // In contrary to RPUSH, no operation will be performed when key does not yet exist.
-func (r *Redis) RPushx(key, value string) (int64, error) {
-	rp, err := r.ExecuteCommand("RPUSHX", key, value)
+// Multiple values need Redis 4.0.
+func (r *Redis) RPushx(key string, values ...string) (int64, error) {
+	args := packArgs("RPUSHX", key, values)
+	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return 0, err
 	}
@@ -26,6 +26,36 @@ func TestBLPop(t *testing.T) {
 	}
 }
 
+func TestBLMove(t *testing.T) {
+	r.Del("key", "key1")
+	if result, err := r.BLMove("key", "key1", ListRight, ListLeft, 1); err != nil {
+		t.Error(err)
+	} else if result != nil {
+		t.Fail()
+	}
+	r.RPush("key", "a", "b")
+	if result, err := r.BLMove("key", "key1", ListLeft, ListRight, 1); err != nil {
+		t.Error(err)
+	} else if string(result) != "a" {
+		t.Fail()
+	}
+}
+
+func TestBLMPop(t *testing.T) {
+	r.Del("key", "key1")
+	if key, elements, err := r.BLMPop(1, ListLeft, 0, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "" || elements != nil {
+		t.Fail()
+	}
+	r.RPush("key1", "a", "b", "c")
+	if key, elements, err := r.BLMPop(1, ListRight, 2, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "key1" || len(elements) != 2 || elements[0] != "c" {
+		t.Fail()
+	}
+}
+
 func TestBRPop(t *testing.T) {
 	r.Del("key")
 	result, err := r.BRPop([]string{"key"}, 1)
@@ -100,6 +130,42 @@ func TestLLen(t *testing.T) {
 	}
 }
 
+func TestLMove(t *testing.T) {
+	r.Del("key", "key1")
+	r.RPush("key", "a", "b", "c")
+	if result, err := r.LMove("key", "key1", ListRight, ListLeft); err != nil {
+		t.Error(err)
+	} else if string(result) != "c" {
+		t.Fail()
+	}
+	if result, _ := r.LMove("key", "key", ListLeft, ListRight); string(result) != "a" {
+		t.Fail()
+	}
+	if result, _ := r.LMove("nokey", "key1", ListLeft, ListLeft); result != nil {
+		t.Fail()
+	}
+}
+
+func TestLMPop(t *testing.T) {
+	r.Del("key", "key1")
+	if key, elements, err := r.LMPop(ListLeft, 0, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "" || elements != nil {
+		t.Fail()
+	}
+	r.RPush("key1", "a", "b", "c")
+	if key, elements, err := r.LMPop(ListLeft, 0, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "key1" || len(elements) != 1 || elements[0] != "a" {
+		t.Fail()
+	}
+	if key, elements, err := r.LMPop(ListRight, 5, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "key1" || len(elements) != 2 || elements[0] != "c" {
+		t.Fail()
+	}
+}
+
 func TestLPop(t *testing.T) {
 	r.Del("key")
 	r.RPush("key", "one", "two", "three")
@@ -114,6 +180,45 @@ func TestLPop(t *testing.T) {
 	}
 }
 
+func TestLPopCount(t *testing.T) {
+	r.Del("key")
+	if result, err := r.LPopCount("key", 2); err != nil {
+		t.Error(err)
+	} else if result != nil {
+		t.Fail()
+	}
+	r.RPush("key", "a", "b", "c")
+	if result, err := r.LPopCount("key", 2); err != nil {
+		t.Error(err)
+	} else if len(result) != 2 || result[0] != "a" || result[1] != "b" {
+		t.Fail()
+	}
+}
+
+func TestLPos(t *testing.T) {
+	r.Del("key")
+	r.RPush("key", "a", "b", "c", "1", "2", "3", "c", "c")
+	if n, err := r.LPos("key", "c", nil); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Fail()
+	}
+	if n, _ := r.LPos("key", "c", &LPosArgs{Rank: -1}); n != 7 {
+		t.Fail()
+	}
+	if n, _ := r.LPos("key", "c", &LPosArgs{MaxLen: 2}); n != -1 {
+		t.Fail()
+	}
+	if indexes, err := r.LPosCount("key", "c", 2, &LPosArgs{Rank: 2}); err != nil {
+		t.Error(err)
+	} else if len(indexes) != 2 || indexes[0] != 6 || indexes[1] != 7 {
+		t.Fail()
+	}
+	if indexes, _ := r.LPosCount("key", "c", 0, nil); len(indexes) != 3 {
+		t.Fail()
+	}
+}
+
 func TestLPush(t *testing.T) {
 	r.Del("key")
 	if n, err := r.LPush("key", "value"); err != nil {
@@ -141,6 +246,9 @@ func TestLPushx(t *testing.T) {
 	if n, _ := r.LPushx("key", "value"); n != 2 {
 		t.Fail()
 	}
+	if n, _ := r.LPushx("key", "a", "b"); n != 4 {
+		t.Fail()
+	}
 }
 
 func TestLRange(t *testing.T) {
@@ -209,6 +317,16 @@ func TestRPop(t *testing.T) {
 	}
 }
 
+func TestRPopCount(t *testing.T) {
+	r.Del("key")
+	r.RPush("key", "a", "b", "c")
+	if result, err := r.RPopCount("key", 5); err != nil {
+		t.Error(err)
+	} else if len(result) != 3 || result[0] != "c" {
+		t.Fail()
+	}
+}
+
 func TestRPopLPush(t *testing.T) {
 	r.Del("key")
 	if value, err := r.RPopLPush("key", "key"); err != nil {
@@ -234,4 +352,8 @@ func TestRPushx(t *testing.T) {
 	} else if n != 0 {
 		t.Fail()
 	}
+	r.RPush("key", "value")
+	if n, _ := r.RPushx("key", "a", "b"); n != 3 {
+		t.Fail()
+	}
 }
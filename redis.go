@@ -176,6 +176,8 @@ func packCommand(args ...interface{}) ([]byte, error) {
 type connection struct {
 	Conn   net.Conn
 	Reader *bufio.Reader
+
+	epoch int
 }
 
 func (c *connection) SendCommand(args ...interface{}) error {
@@ -268,6 +270,7 @@ type connPool struct {
 
 	idle   *list.List
 	closed bool
+	epoch  int
 	mutex  sync.Mutex
 }
 
@@ -280,6 +283,28 @@ func (p *connPool) Close() {
 	p.mutex.Unlock()
 }
 
+// Reset closes all the idle connections and makes the pool dial new ones with dial.
+// Connections taken out before the reset are closed instead of being put back.
+func (p *connPool) Reset(dial func() (*connection, error)) {
+	p.mutex.Lock()
+	for e := p.idle.Front(); e != nil; e = e.Next() {
+		e.Value.(*connection).Conn.Close()
+	}
+	p.idle.Init()
+	p.Dial = dial
+	p.epoch++
+	p.mutex.Unlock()
+}
+
+// dial dials a new connection the way Get does when no connection is idle,
+// for the connections which are never put back.
+func (p *connPool) dial() (*connection, error) {
+	p.mutex.Lock()
+	dial := p.Dial
+	p.mutex.Unlock()
+	return dial()
+}
+
 func (p *connPool) Get() (*connection, error) {
 	p.mutex.Lock()
 	if p.closed {
@@ -292,8 +317,14 @@ func (p *connPool) Get() (*connection, error) {
 		p.mutex.Unlock()
 		return back.Value.(*connection), nil
 	}
+	dial, epoch := p.Dial, p.epoch
 	p.mutex.Unlock()
-	return p.Dial()
+	c, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	c.epoch = epoch
+	return c, nil
 }
 
 func (p *connPool) Put(c *connection) {
@@ -302,7 +333,7 @@ func (p *connPool) Put(c *connection) {
 		p.mutex.Unlock()
 		return
 	}
-	if p.closed {
+	if p.closed || c.epoch != p.epoch {
 		c.Conn.Close()
 		p.mutex.Unlock()
 		return
@@ -322,12 +353,38 @@ type Redis struct {
 	db       int
 	password string
 	timeout  time.Duration
+	readOnly bool
 	pool     *connPool
+
+	// route picks the pool a command is sent to, nil sends every command to pool.
+	route func(args []interface{}) *connPool
+	// fallback returns the pool to send a command again on after it failed on a pool picked by route,
+	// nil to return the error. Only the commands which can be sent twice, like reads, may be retried.
+	fallback func(pool *connPool) *connPool
+
+	// scripts are loaded on every new connection, see PreloadScripts.
+	scripts *ScriptRegistry
 }
 
 // ExecuteCommand send any raw redis command and receive reply from redis server
 func (r *Redis) ExecuteCommand(args ...interface{}) (*Reply, error) {
-	c, err := r.pool.Get()
+	pool := r.pool
+	if r.route != nil {
+		pool = r.route(args)
+	}
+	rp, err := executeOn(pool, args)
+	if err != nil && r.fallback != nil {
+		if pool = r.fallback(pool); pool != nil {
+			return executeOn(pool, args)
+		}
+	}
+	return rp, err
+}
+
+// executeOn sends args on a connection of pool and receives the reply,
+// on a new connection if the server closed the idle one.
+func executeOn(pool *connPool, args []interface{}) (*Reply, error) {
+	c, err := pool.Get()
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +392,7 @@ func (r *Redis) ExecuteCommand(args ...interface{}) (*Reply, error) {
 		if err != io.EOF {
 			return nil, err
 		}
-		c, err = r.pool.Get()
+		c, err = pool.Get()
 		if err != nil {
 			return nil, err
 		}
@@ -348,7 +405,7 @@ func (r *Redis) ExecuteCommand(args ...interface{}) (*Reply, error) {
 		if err != io.EOF {
 			return nil, err
 		}
-		c, err = r.pool.Get()
+		c, err = pool.Get()
 		if err != nil {
 			return nil, err
 		}
@@ -358,17 +415,21 @@ func (r *Redis) ExecuteCommand(args ...interface{}) (*Reply, error) {
 		rp, err = c.RecvReply()
 	}
 	if err == nil {
-		r.pool.Put(c)
+		pool.Put(c)
 	}
 	return rp, err
 }
 
 func (r *Redis) dialConnection() (*connection, error) {
-	conn, err := net.DialTimeout(r.network, r.address, r.timeout)
+	return r.dialAddress(r.address)
+}
+
+func (r *Redis) dialAddress(address string) (*connection, error) {
+	conn, err := net.DialTimeout(r.network, address, r.timeout)
 	if err != nil {
 		return nil, err
 	}
-	c := &connection{conn, bufio.NewReader(conn)}
+	c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
 	if r.password != "" {
 		if err := c.SendCommand("AUTH", r.password); err != nil {
 			return nil, err
@@ -393,6 +454,24 @@ func (r *Redis) dialConnection() (*connection, error) {
 			return nil, errors.New(rp.Error)
 		}
 	}
+	if r.readOnly {
+		if err := c.SendCommand("READONLY"); err != nil {
+			return nil, err
+		}
+		rp, err := c.RecvReply()
+		if err != nil {
+			return nil, err
+		}
+		if rp.Type == ErrorReply {
+			return nil, errors.New(rp.Error)
+		}
+	}
+	if r.scripts != nil {
+		if err := r.scripts.loadConnection(c); err != nil {
+			c.Conn.Close()
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
@@ -472,6 +551,17 @@ func newDialConfigFromURLString(rawurl string) (*DialConfig, error) {
 
 // Dial new a redis client with DialConfig
 func Dial(cfg *DialConfig) (*Redis, error) {
+	r := newRedis(cfg)
+	conn, err := r.dialConnection()
+	if err != nil {
+		return nil, err
+	}
+	r.pool.Put(conn)
+	return r, nil
+}
+
+// newRedis fills the defaults of cfg and new a redis client without connecting.
+func newRedis(cfg *DialConfig) *Redis {
 	if cfg == nil {
 		cfg = &DialConfig{}
 	}
@@ -499,12 +589,7 @@ func Dial(cfg *DialConfig) (*Redis, error) {
 		Dial:    r.dialConnection,
 		idle:    list.New(),
 	}
-	conn, err := r.dialConnection()
-	if err != nil {
-		return nil, err
-	}
-	r.pool.Put(conn)
-	return r, nil
+	return r
 }
 
 // DialURL new a redis client with URL-like argument
@@ -690,6 +775,17 @@ func (rp *Reply) BytesArrayValue() ([][]byte, error) {
 	return result, nil
 }
 
+// field returns the value following name in a multi bulk of name value pairs,
+// or a nil bulk reply if there is no such name.
+func (rp *Reply) field(name string) *Reply {
+	for i := 0; i+1 < len(rp.Multi); i += 2 {
+		if key, err := rp.Multi[i].StringValue(); err == nil && key == name {
+			return rp.Multi[i+1]
+		}
+	}
+	return &Reply{Type: BulkReply}
+}
+
 // BoolArrayValue indicates redis reply a multi value
 // each bulk is an integer(bool)
 func (rp *Reply) BoolArrayValue() ([]bool, error) {
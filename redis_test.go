@@ -1,7 +1,11 @@
 package goredis
 
 import (
+	"bufio"
 	"fmt"
+	"net"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 )
@@ -148,3 +152,139 @@ func TestNewDialConfigFromURL(t *testing.T) {
 		}
 	}
 }
+
+// fakeStatus is replied by a fakeServer handler as a status reply.
+type fakeStatus string
+
+// fakeReplies is replied by a fakeServer handler as several replies in a row.
+type fakeReplies []interface{}
+
+// fakeServer speaks just enough of the redis protocol to stand in for
+// servers the tests can not start, like sentinels or extra shards.
+// handler returns nil, string, fakeStatus, int, error, []string, []interface{} or fakeReplies.
+type fakeServer struct {
+	listener net.Listener
+	handler  func(args []string) interface{}
+
+	mutex       sync.Mutex
+	conns       []net.Conn
+	subscribers map[string][]net.Conn
+}
+
+func newFakeServer(t testing.TB, handler func(args []string) interface{}) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{
+		listener:    listener,
+		handler:     handler,
+		subscribers: make(map[string][]net.Conn),
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeServer) Close() {
+	s.listener.Close()
+	s.mutex.Lock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+}
+
+func (s *fakeServer) Subscribers(channel string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.subscribers[channel])
+}
+
+func (s *fakeServer) Publish(channel, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, conn := range s.subscribers[channel] {
+		w := bufio.NewWriter(conn)
+		writeFakeReply(w, []string{"message", channel, message})
+		w.Flush()
+	}
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mutex.Lock()
+		s.conns = append(s.conns, conn)
+		s.mutex.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
+	for {
+		rp, err := c.RecvReply()
+		if err != nil {
+			return
+		}
+		args, err := rp.ListValue()
+		if err != nil || len(args) == 0 {
+			return
+		}
+		s.mutex.Lock()
+		w := bufio.NewWriter(conn)
+		switch args[0] {
+		case "SUBSCRIBE":
+			for i, channel := range args[1:] {
+				s.subscribers[channel] = append(s.subscribers[channel], conn)
+				writeFakeReply(w, []interface{}{"subscribe", channel, i + 1})
+			}
+		default:
+			s.mutex.Unlock()
+			v := s.handler(args)
+			s.mutex.Lock()
+			writeFakeReply(w, v)
+		}
+		w.Flush()
+		s.mutex.Unlock()
+	}
+}
+
+func writeFakeReply(w *bufio.Writer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		w.WriteString("$" + strconv.Itoa(len(v)) + "\r\n" + v + "\r\n")
+	case fakeStatus:
+		w.WriteString("+" + string(v) + "\r\n")
+	case int:
+		w.WriteString(":" + strconv.Itoa(v) + "\r\n")
+	case error:
+		w.WriteString("-" + v.Error() + "\r\n")
+	case []string:
+		w.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, item := range v {
+			writeFakeReply(w, item)
+		}
+	case []interface{}:
+		w.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, item := range v {
+			writeFakeReply(w, item)
+		}
+	case fakeReplies:
+		for _, item := range v {
+			writeFakeReply(w, item)
+		}
+	default:
+		panic(fmt.Sprintf("fake server can not reply %T", v))
+	}
+}
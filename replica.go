@@ -0,0 +1,298 @@
+package goredis
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Replica selection policies of ReplicaConfig.
+const (
+	RandomReplica = iota
+	RoundRobinReplica
+	LowestLatencyReplica
+)
+
+// DefaultCheckInterval is the default interval between two replica health checks.
+const DefaultCheckInterval = 5 * time.Second
+
+// readOnlyCommands are the commands a ReplicaClient may send to a replica.
+var readOnlyCommands = map[string]bool{
+	"BITCOUNT": true, "BITFIELD_RO": true, "BITPOS": true, "DUMP": true,
+	"EVAL_RO": true, "EVALSHA_RO": true, "EXISTS": true, "EXPIRETIME": true,
+	"FCALL_RO": true, "GEODIST": true, "GEOHASH": true, "GEOPOS": true,
+	"GEOSEARCH": true, "GET": true, "GETBIT": true, "GETRANGE": true,
+	"HEXISTS": true, "HGET": true, "HGETALL": true, "HKEYS": true,
+	"HLEN": true, "HMGET": true, "HRANDFIELD": true, "HSCAN": true,
+	"HSTRLEN": true, "HVALS": true, "KEYS": true, "LCS": true,
+	"LINDEX": true, "LLEN": true, "LPOS": true, "LRANGE": true,
+	"MGET": true, "PEXPIRETIME": true, "PFCOUNT": true, "PTTL": true,
+	"RANDOMKEY": true, "SCAN": true, "SCARD": true, "SDIFF": true,
+	"SINTER": true, "SINTERCARD": true, "SISMEMBER": true, "SMEMBERS": true,
+	"SMISMEMBER": true, "SORT_RO": true, "SRANDMEMBER": true, "SSCAN": true,
+	"STRLEN": true, "SUNION": true, "TTL": true, "TYPE": true,
+	"XLEN": true, "XRANGE": true, "XREVRANGE": true, "ZCARD": true,
+	"ZCOUNT": true, "ZDIFF": true, "ZINTER": true, "ZINTERCARD": true,
+	"ZLEXCOUNT": true, "ZMSCORE": true, "ZRANDMEMBER": true, "ZRANGE": true,
+	"ZRANGEBYLEX": true, "ZRANGEBYSCORE": true, "ZRANK": true, "ZREVRANGE": true,
+	"ZREVRANGEBYLEX": true, "ZREVRANGEBYSCORE": true, "ZREVRANK": true, "ZSCAN": true,
+	"ZSCORE": true, "ZUNION": true,
+}
+
+// ReplicaConfig is the parameters of a ReplicaClient.
+//
+// Replicas returns the replica addresses(host:port), it is called on every health check.
+// StaticReplicas, FailoverClient.Replicas and Redis.ClusterShardReplicas can be used here.
+// A replica is skipped when its link with the master is down,
+// or when MaxLag is set and the last interaction with the master is older than MaxLag.
+// ReadOnly sends READONLY on each replica connection, which cluster replicas require.
+type ReplicaConfig struct {
+	Replicas      func() ([]string, error)
+	Policy        int
+	MaxLag        time.Duration
+	ReadOnly      bool
+	CheckInterval time.Duration
+}
+
+// StaticReplicas returns a ReplicaConfig.Replicas which always gives addresses.
+func StaticReplicas(addresses ...string) func() ([]string, error) {
+	return func() ([]string, error) {
+		return addresses, nil
+	}
+}
+
+type replica struct {
+	address string
+	redis   *Redis
+	latency time.Duration
+}
+
+// ReplicaClient sends read-only commands to a healthy replica, and everything else to the master.
+// When no replica is healthy read-only commands go to the master too.
+// A read which fails on a replica, like one which died since the last health check,
+// is sent again to the master, and the replica is skipped until the next health check.
+// Pipelining, Transaction, PubSub and Monitor always use the master.
+type ReplicaClient struct {
+	*Redis
+
+	master *Redis
+	config ReplicaConfig
+
+	mutex    sync.Mutex
+	replicas map[string]*replica
+	healthy  []*replica
+	next     int
+	quit     chan bool
+	closed   bool
+}
+
+// ReplicaClient new a *ReplicaClient which uses r as the master.
+// The replicas are checked once before it returns, and then every cfg.CheckInterval.
+func (r *Redis) ReplicaClient(cfg *ReplicaConfig) (*ReplicaClient, error) {
+	if cfg == nil || cfg.Replicas == nil {
+		return nil, errors.New("replica: no replica source")
+	}
+	c := &ReplicaClient{
+		master:   r,
+		config:   *cfg,
+		replicas: make(map[string]*replica),
+		quit:     make(chan bool),
+	}
+	if c.config.CheckInterval == 0 {
+		c.config.CheckInterval = DefaultCheckInterval
+	}
+	redis := *r
+	redis.route = c.route
+	redis.fallback = c.fallback
+	c.Redis = &redis
+	c.check()
+	go c.run()
+	return c, nil
+}
+
+// Replicas returns the addresses of the replicas which passed the last health check.
+func (c *ReplicaClient) Replicas() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	addresses := make([]string, 0, len(c.healthy))
+	for _, rep := range c.healthy {
+		addresses = append(addresses, rep.address)
+	}
+	return addresses
+}
+
+// Close stops the health checks and closes the replica connections.
+// The master is left open.
+func (c *ReplicaClient) Close() {
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.quit)
+	for _, rep := range c.replicas {
+		rep.redis.ClosePool()
+	}
+	c.replicas = nil
+	c.healthy = nil
+	c.mutex.Unlock()
+}
+
+func (c *ReplicaClient) route(args []interface{}) *connPool {
+	if len(args) == 0 {
+		return c.master.pool
+	}
+	name, ok := args[0].(string)
+	if !ok || !readOnlyCommands[strings.ToUpper(name)] {
+		return c.master.pool
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.healthy) == 0 {
+		return c.master.pool
+	}
+	var rep *replica
+	switch c.config.Policy {
+	case RoundRobinReplica:
+		rep = c.healthy[c.next%len(c.healthy)]
+		c.next++
+	case LowestLatencyReplica:
+		rep = c.healthy[0]
+		for _, h := range c.healthy[1:] {
+			if h.latency < rep.latency {
+				rep = h
+			}
+		}
+	default:
+		rep = c.healthy[rand.Intn(len(c.healthy))]
+	}
+	return rep.redis.pool
+}
+
+// fallback marks the replica of pool unhealthy, and returns the master pool to send the read again on.
+// A pool closed by check or Close, but picked by route before, also ends here.
+func (c *ReplicaClient) fallback(pool *connPool) *connPool {
+	if pool == c.master.pool {
+		return nil
+	}
+	c.mutex.Lock()
+	for i, rep := range c.healthy {
+		if rep.redis.pool == pool {
+			c.healthy = append(c.healthy[:i:i], c.healthy[i+1:]...)
+			break
+		}
+	}
+	c.mutex.Unlock()
+	return c.master.pool
+}
+
+func (c *ReplicaClient) run() {
+	ticker := time.NewTicker(c.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quit:
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+// check refreshes the replica addresses and measures every replica.
+func (c *ReplicaClient) check() {
+	addresses, err := c.config.Replicas()
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return
+	}
+	if err == nil {
+		known := make(map[string]bool)
+		for _, address := range addresses {
+			known[address] = true
+			if c.replicas[address] == nil {
+				redis := newRedis(&DialConfig{
+					Network:  c.master.network,
+					Address:  address,
+					Database: c.master.db,
+					Password: c.master.password,
+					Timeout:  c.master.timeout,
+					MaxIdle:  c.master.pool.MaxIdle,
+				})
+				redis.readOnly = c.config.ReadOnly
+				c.replicas[address] = &replica{address: address, redis: redis}
+			}
+		}
+		for address, rep := range c.replicas {
+			if !known[address] {
+				rep.redis.ClosePool()
+				delete(c.replicas, address)
+			}
+		}
+		healthy := c.healthy[:0:0]
+		for _, rep := range c.healthy {
+			if known[rep.address] {
+				healthy = append(healthy, rep)
+			}
+		}
+		c.healthy = healthy
+	}
+	replicas := make([]*replica, 0, len(c.replicas))
+	for _, rep := range c.replicas {
+		replicas = append(replicas, rep)
+	}
+	c.mutex.Unlock()
+
+	var healthy []*replica
+	latencies := make(map[*replica]time.Duration)
+	for _, rep := range replicas {
+		if latency, err := c.measure(rep.redis); err == nil {
+			healthy = append(healthy, rep)
+			latencies[rep] = latency
+		}
+	}
+
+	c.mutex.Lock()
+	if !c.closed {
+		for rep, latency := range latencies {
+			rep.latency = latency
+		}
+		c.healthy = healthy
+	}
+	c.mutex.Unlock()
+}
+
+// measure returns the PING latency of a replica, or an error if it should not serve reads.
+func (c *ReplicaClient) measure(redis *Redis) (time.Duration, error) {
+	start := time.Now()
+	if err := redis.Ping(); err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+	info, err := redis.Info("replication")
+	if err != nil {
+		return 0, err
+	}
+	fields := infoFields(info)
+	if fields["role"] != "slave" {
+		return 0, errors.New("replica: not a replica")
+	}
+	if fields["master_link_status"] != "up" {
+		return 0, errors.New("replica: master link is down")
+	}
+	if c.config.MaxLag > 0 {
+		seconds, err := strconv.Atoi(fields["master_last_io_seconds_ago"])
+		if err != nil {
+			return 0, err
+		}
+		if time.Duration(seconds)*time.Second > c.config.MaxLag {
+			return 0, errors.New("replica: replication lag too large")
+		}
+	}
+	return latency, nil
+}
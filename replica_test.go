@@ -0,0 +1,156 @@
+package goredis
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeReplica struct {
+	*fakeServer
+	mutex    sync.Mutex
+	readOnly int
+	info     string
+}
+
+func newFakeReplica(t *testing.T, value string) *fakeReplica {
+	f := &fakeReplica{info: "# Replication\r\nrole:slave\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:0\r\n"}
+	f.fakeServer = newFakeServer(t, func(args []string) interface{} {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		switch args[0] {
+		case "READONLY":
+			f.readOnly++
+			return fakeStatus("OK")
+		case "SELECT":
+			return fakeStatus("OK")
+		case "PING":
+			return fakeStatus("PONG")
+		case "INFO":
+			return f.info
+		case "GET":
+			return value
+		}
+		return errors.New("READONLY You can't write against a read only replica.")
+	})
+	return f
+}
+
+func (f *fakeReplica) setInfo(info string) {
+	f.mutex.Lock()
+	f.info = info
+	f.mutex.Unlock()
+}
+
+func TestReplicaClient(t *testing.T) {
+	replica := newFakeReplica(t, "replica")
+	defer replica.Close()
+	c, err := r.ReplicaClient(&ReplicaConfig{
+		Replicas:      StaticReplicas(replica.Addr()),
+		Policy:        RoundRobinReplica,
+		ReadOnly:      true,
+		CheckInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Set("key", "master", 0, 0, false, false); err != nil {
+		t.Error(err)
+	}
+	if value, err := c.Get("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "replica" {
+		t.Fail()
+	}
+	if value, err := r.Get("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "master" {
+		t.Fail()
+	}
+	replica.mutex.Lock()
+	if replica.readOnly == 0 {
+		t.Error("READONLY not sent")
+	}
+	replica.mutex.Unlock()
+
+	replica.setInfo("role:slave\r\nmaster_link_status:down\r\n")
+	for i := 0; len(c.Replicas()) != 0; i++ {
+		if i > 100 {
+			t.Fatal("replica with link down still used")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if value, err := c.Get("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "master" {
+		t.Fail()
+	}
+}
+
+func TestReplicaClientMaxLag(t *testing.T) {
+	fresh := newFakeReplica(t, "fresh")
+	defer fresh.Close()
+	stale := newFakeReplica(t, "stale")
+	defer stale.Close()
+	stale.setInfo("role:slave\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:30\r\n")
+	c, err := r.ReplicaClient(&ReplicaConfig{
+		Replicas: StaticReplicas(stale.Addr(), fresh.Addr()),
+		Policy:   LowestLatencyReplica,
+		MaxLag:   10 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if replicas := c.Replicas(); len(replicas) != 1 || replicas[0] != fresh.Addr() {
+		t.Fail()
+	}
+	for i := 0; i < 3; i++ {
+		if value, err := c.Get("key"); err != nil {
+			t.Error(err)
+		} else if string(value) != "fresh" {
+			t.Fail()
+		}
+	}
+}
+
+func TestReplicaClientFallback(t *testing.T) {
+	fake := newFakeReplica(t, "replica")
+	c, err := r.ReplicaClient(&ReplicaConfig{
+		Replicas:      StaticReplicas(fake.Addr()),
+		CheckInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := r.Set("key", "master", 0, 0, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := c.Get("key"); err != nil || string(value) != "replica" {
+		t.Fatal(string(value), err)
+	}
+	// The replica dies between two health checks.
+	fake.Close()
+	if value, err := c.Get("key"); err != nil || string(value) != "master" {
+		t.Error(string(value), err)
+	}
+	if replicas := c.Replicas(); len(replicas) != 0 {
+		t.Error(replicas)
+	}
+
+	// A replica closed by a health check while route still picks it.
+	closed := &replica{address: fake.Addr(), redis: newRedis(&DialConfig{Address: fake.Addr()})}
+	closed.redis.ClosePool()
+	c.mutex.Lock()
+	c.healthy = []*replica{closed}
+	c.mutex.Unlock()
+	if value, err := c.Get("key"); err != nil || string(value) != "master" {
+		t.Error(string(value), err)
+	}
+	if replicas := c.Replicas(); len(replicas) != 0 {
+		t.Error(replicas)
+	}
+}
@@ -0,0 +1,697 @@
+package goredis
+
+import (
+	"errors"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultVirtualNodes is the default number of points each shard has on the ring.
+const DefaultVirtualNodes = 160
+
+// RingConfig is the parameters of a Ring.
+// Shards maps a shard name to the server holding it,
+// keys are placed by shard name so a shard can move to another address without moving keys.
+type RingConfig struct {
+	Shards        map[string]*DialConfig
+	VirtualNodes  int
+	CheckInterval time.Duration
+}
+
+type ringPoint struct {
+	hash  uint32
+	shard string
+}
+
+// Ring shards keys across independent redis servers with consistent hashing.
+// Only the part of a key inside the first {...} is hashed if it is not empty,
+// so keys sharing a hash tag always live on the same shard.
+//
+// Every shard is PINGed each CheckInterval,
+// a shard which fails is taken out of the ring until it answers again.
+// Commands which are not exposed by Ring can be sent to Shard(key).
+type Ring struct {
+	virtualNodes int
+	shards       map[string]*Redis
+
+	mutex  sync.RWMutex
+	alive  []string
+	points []ringPoint
+	all    []ringPoint
+	quit   chan bool
+	closed bool
+}
+
+// DialRing new a *Ring, the shards are connected lazily.
+func DialRing(cfg *RingConfig) (*Ring, error) {
+	if cfg == nil || len(cfg.Shards) == 0 {
+		return nil, errors.New("ring: no shard")
+	}
+	r := &Ring{
+		virtualNodes: cfg.VirtualNodes,
+		shards:       make(map[string]*Redis),
+		quit:         make(chan bool),
+	}
+	if r.virtualNodes <= 0 {
+		r.virtualNodes = DefaultVirtualNodes
+	}
+	names := make([]string, 0, len(cfg.Shards))
+	for name, dialConfig := range cfg.Shards {
+		if dialConfig == nil {
+			return nil, errors.New("ring: no dial config for shard " + name)
+		}
+		c := *dialConfig
+		r.shards[name] = newRedis(&c)
+		names = append(names, name)
+	}
+	r.all = r.buildPoints(names)
+	r.check()
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	go r.run(interval)
+	return r, nil
+}
+
+// hashTag returns the part of key which is hashed to place it,
+// the content of the first non-empty {...} or the whole key.
+func hashTag(key string) string {
+	if s := strings.IndexByte(key, '{'); s >= 0 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			return key[s+1 : s+1+e]
+		}
+	}
+	return key
+}
+
+func (r *Ring) buildPoints(names []string) []ringPoint {
+	points := make([]ringPoint, 0, len(names)*r.virtualNodes)
+	for _, name := range names {
+		for i := 0; i < r.virtualNodes; i++ {
+			hash := crc32.ChecksumIEEE([]byte(name + "-" + strconv.Itoa(i)))
+			points = append(points, ringPoint{hash, name})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].hash == points[j].hash {
+			return points[i].shard < points[j].shard
+		}
+		return points[i].hash < points[j].hash
+	})
+	return points
+}
+
+// ShardName returns the name of the shard key belongs to.
+// When every shard is down keys are placed as if all the shards were alive.
+func (r *Ring) ShardName(key string) string {
+	r.mutex.RLock()
+	points := r.points
+	if len(points) == 0 {
+		points = r.all
+	}
+	r.mutex.RUnlock()
+	hash := crc32.ChecksumIEEE([]byte(hashTag(key)))
+	i := sort.Search(len(points), func(i int) bool { return points[i].hash >= hash })
+	if i == len(points) {
+		i = 0
+	}
+	return points[i].shard
+}
+
+// Shard returns the client of the shard key belongs to.
+func (r *Ring) Shard(key string) *Redis {
+	return r.shards[r.ShardName(key)]
+}
+
+// Shards returns the names of the shards currently in the ring.
+func (r *Ring) Shards() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]string(nil), r.alive...)
+}
+
+// ForEachShard calls fn for every shard currently in the ring,
+// it stops at the first error.
+func (r *Ring) ForEachShard(fn func(name string, shard *Redis) error) error {
+	for _, name := range r.Shards() {
+		if err := fn(name, r.shards[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the health checks and closes all the shard clients.
+func (r *Ring) Close() {
+	r.mutex.Lock()
+	if r.closed {
+		r.mutex.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.quit)
+	r.mutex.Unlock()
+	for _, shard := range r.shards {
+		shard.ClosePool()
+	}
+}
+
+func (r *Ring) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+// check PINGs every shard and rebuilds the ring when the set of live shards changed.
+func (r *Ring) check() {
+	var alive []string
+	for name, shard := range r.shards {
+		if err := shard.Ping(); err == nil {
+			alive = append(alive, name)
+		}
+	}
+	sort.Strings(alive)
+	r.mutex.RLock()
+	changed := strings.Join(alive, "\n") != strings.Join(r.alive, "\n")
+	r.mutex.RUnlock()
+	if !changed {
+		return
+	}
+	points := r.buildPoints(alive)
+	r.mutex.Lock()
+	r.alive = alive
+	r.points = points
+	r.mutex.Unlock()
+}
+
+// groupKeys groups keys by shard name, keeping the positions of every key.
+func (r *Ring) groupKeys(keys []string) map[string][]int {
+	groups := make(map[string][]int)
+	for i, key := range keys {
+		name := r.ShardName(key)
+		groups[name] = append(groups[name], i)
+	}
+	return groups
+}
+
+// Del removes the specified keys from their shards.
+// Integer reply: The number of keys that were removed.
+func (r *Ring) Del(keys ...string) (int64, error) {
+	var n int64
+	for name, positions := range r.groupKeys(keys) {
+		shardKeys := make([]string, len(positions))
+		for i, pos := range positions {
+			shardKeys[i] = keys[pos]
+		}
+		removed, err := r.shards[name].Del(shardKeys...)
+		if err != nil {
+			return n, err
+		}
+		n += removed
+	}
+	return n, nil
+}
+
+// MGet returns the values of all specified keys, in the order of keys.
+// Keys on different shards are not read atomically.
+func (r *Ring) MGet(keys ...string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for name, positions := range r.groupKeys(keys) {
+		shardKeys := make([]string, len(positions))
+		for i, pos := range positions {
+			shardKeys[i] = keys[pos]
+		}
+		shardValues, err := r.shards[name].MGet(shardKeys...)
+		if err != nil {
+			return nil, err
+		}
+		if len(shardValues) != len(positions) {
+			return nil, errors.New("ring: mget protocol error")
+		}
+		for i, pos := range positions {
+			values[pos] = shardValues[i]
+		}
+	}
+	return values, nil
+}
+
+// MSet sets the given keys to their respective values.
+// Keys on different shards are not set atomically.
+func (r *Ring) MSet(pairs map[string]string) error {
+	groups := make(map[string]map[string]string)
+	for key, value := range pairs {
+		name := r.ShardName(key)
+		if groups[name] == nil {
+			groups[name] = make(map[string]string)
+		}
+		groups[name][key] = value
+	}
+	for name, group := range groups {
+		if err := r.shards[name].MSet(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append calls Redis.Append on the shard key belongs to.
+func (r *Ring) Append(key, value string) (int64, error) {
+	return r.Shard(key).Append(key, value)
+}
+
+// BitCount calls Redis.BitCount on the shard key belongs to.
+func (r *Ring) BitCount(key string, start, end int) (int64, error) {
+	return r.Shard(key).BitCount(key, start, end)
+}
+
+// Decr calls Redis.Decr on the shard key belongs to.
+func (r *Ring) Decr(key string) (int64, error) {
+	return r.Shard(key).Decr(key)
+}
+
+// DecrBy calls Redis.DecrBy on the shard key belongs to.
+func (r *Ring) DecrBy(key string, decrement int) (int64, error) {
+	return r.Shard(key).DecrBy(key, decrement)
+}
+
+// Get calls Redis.Get on the shard key belongs to.
+func (r *Ring) Get(key string) ([]byte, error) {
+	return r.Shard(key).Get(key)
+}
+
+// GetBit calls Redis.GetBit on the shard key belongs to.
+func (r *Ring) GetBit(key string, offset int) (int64, error) {
+	return r.Shard(key).GetBit(key, offset)
+}
+
+// GetRange calls Redis.GetRange on the shard key belongs to.
+func (r *Ring) GetRange(key string, start, end int) (string, error) {
+	return r.Shard(key).GetRange(key, start, end)
+}
+
+// GetSet calls Redis.GetSet on the shard key belongs to.
+func (r *Ring) GetSet(key, value string) ([]byte, error) {
+	return r.Shard(key).GetSet(key, value)
+}
+
+// Incr calls Redis.Incr on the shard key belongs to.
+func (r *Ring) Incr(key string) (int64, error) {
+	return r.Shard(key).Incr(key)
+}
+
+// IncrBy calls Redis.IncrBy on the shard key belongs to.
+func (r *Ring) IncrBy(key string, increment int) (int64, error) {
+	return r.Shard(key).IncrBy(key, increment)
+}
+
+// IncrByFloat calls Redis.IncrByFloat on the shard key belongs to.
+func (r *Ring) IncrByFloat(key string, increment float64) (float64, error) {
+	return r.Shard(key).IncrByFloat(key, increment)
+}
+
+// PSetex calls Redis.PSetex on the shard key belongs to.
+func (r *Ring) PSetex(key string, milliseconds int, value string) error {
+	return r.Shard(key).PSetex(key, milliseconds, value)
+}
+
+// Set calls Redis.Set on the shard key belongs to.
+func (r *Ring) Set(key, value string, seconds, milliseconds int, mustExists, mustNotExists bool) error {
+	return r.Shard(key).Set(key, value, seconds, milliseconds, mustExists, mustNotExists)
+}
+
+// SimpleSet calls Redis.SimpleSet on the shard key belongs to.
+func (r *Ring) SimpleSet(key, value string) error {
+	return r.Shard(key).SimpleSet(key, value)
+}
+
+// SetBit calls Redis.SetBit on the shard key belongs to.
+func (r *Ring) SetBit(key string, offset, value int) (int64, error) {
+	return r.Shard(key).SetBit(key, offset, value)
+}
+
+// Setex calls Redis.Setex on the shard key belongs to.
+func (r *Ring) Setex(key string, seconds int, value string) error {
+	return r.Shard(key).Setex(key, seconds, value)
+}
+
+// Setnx calls Redis.Setnx on the shard key belongs to.
+func (r *Ring) Setnx(key, value string) (bool, error) {
+	return r.Shard(key).Setnx(key, value)
+}
+
+// SetRange calls Redis.SetRange on the shard key belongs to.
+func (r *Ring) SetRange(key string, offset int, value string) (int64, error) {
+	return r.Shard(key).SetRange(key, offset, value)
+}
+
+// StrLen calls Redis.StrLen on the shard key belongs to.
+func (r *Ring) StrLen(key string) (int64, error) {
+	return r.Shard(key).StrLen(key)
+}
+
+// Dump calls Redis.Dump on the shard key belongs to.
+func (r *Ring) Dump(key string) ([]byte, error) {
+	return r.Shard(key).Dump(key)
+}
+
+// Exists calls Redis.Exists on the shard key belongs to.
+func (r *Ring) Exists(key string) (bool, error) {
+	return r.Shard(key).Exists(key)
+}
+
+// Expire calls Redis.Expire on the shard key belongs to.
+func (r *Ring) Expire(key string, seconds int) (bool, error) {
+	return r.Shard(key).Expire(key, seconds)
+}
+
+// ExpireAt calls Redis.ExpireAt on the shard key belongs to.
+func (r *Ring) ExpireAt(key string, timestamp int64) (bool, error) {
+	return r.Shard(key).ExpireAt(key, timestamp)
+}
+
+// Move calls Redis.Move on the shard key belongs to.
+func (r *Ring) Move(key string, db int) (bool, error) {
+	return r.Shard(key).Move(key, db)
+}
+
+// Persist calls Redis.Persist on the shard key belongs to.
+func (r *Ring) Persist(key string) (bool, error) {
+	return r.Shard(key).Persist(key)
+}
+
+// PExpire calls Redis.PExpire on the shard key belongs to.
+func (r *Ring) PExpire(key string, milliseconds int) (bool, error) {
+	return r.Shard(key).PExpire(key, milliseconds)
+}
+
+// PExpireAt calls Redis.PExpireAt on the shard key belongs to.
+func (r *Ring) PExpireAt(key string, timestamp int64) (bool, error) {
+	return r.Shard(key).PExpireAt(key, timestamp)
+}
+
+// PTTL calls Redis.PTTL on the shard key belongs to.
+func (r *Ring) PTTL(key string) (int64, error) {
+	return r.Shard(key).PTTL(key)
+}
+
+// Restore calls Redis.Restore on the shard key belongs to.
+func (r *Ring) Restore(key string, ttl int, serialized string) error {
+	return r.Shard(key).Restore(key, ttl, serialized)
+}
+
+// TTL calls Redis.TTL on the shard key belongs to.
+func (r *Ring) TTL(key string) (int64, error) {
+	return r.Shard(key).TTL(key)
+}
+
+// Type calls Redis.Type on the shard key belongs to.
+func (r *Ring) Type(key string) (string, error) {
+	return r.Shard(key).Type(key)
+}
+
+// HDel calls Redis.HDel on the shard key belongs to.
+func (r *Ring) HDel(key string, fields ...string) (int64, error) {
+	return r.Shard(key).HDel(key, fields...)
+}
+
+// HExists calls Redis.HExists on the shard key belongs to.
+func (r *Ring) HExists(key, field string) (bool, error) {
+	return r.Shard(key).HExists(key, field)
+}
+
+// HGet calls Redis.HGet on the shard key belongs to.
+func (r *Ring) HGet(key, field string) ([]byte, error) {
+	return r.Shard(key).HGet(key, field)
+}
+
+// HGetAll calls Redis.HGetAll on the shard key belongs to.
+func (r *Ring) HGetAll(key string) (map[string]string, error) {
+	return r.Shard(key).HGetAll(key)
+}
+
+// HIncrBy calls Redis.HIncrBy on the shard key belongs to.
+func (r *Ring) HIncrBy(key, field string, increment int) (int64, error) {
+	return r.Shard(key).HIncrBy(key, field, increment)
+}
+
+// HIncrByFloat calls Redis.HIncrByFloat on the shard key belongs to.
+func (r *Ring) HIncrByFloat(key, field string, increment float64) (float64, error) {
+	return r.Shard(key).HIncrByFloat(key, field, increment)
+}
+
+// HKeys calls Redis.HKeys on the shard key belongs to.
+func (r *Ring) HKeys(key string) ([]string, error) {
+	return r.Shard(key).HKeys(key)
+}
+
+// HLen calls Redis.HLen on the shard key belongs to.
+func (r *Ring) HLen(key string) (int64, error) {
+	return r.Shard(key).HLen(key)
+}
+
+// HMGet calls Redis.HMGet on the shard key belongs to.
+func (r *Ring) HMGet(key string, fields ...string) ([][]byte, error) {
+	return r.Shard(key).HMGet(key, fields...)
+}
+
+// HMSet calls Redis.HMSet on the shard key belongs to.
+func (r *Ring) HMSet(key string, pairs map[string]string) error {
+	return r.Shard(key).HMSet(key, pairs)
+}
+
+// HSet calls Redis.HSet on the shard key belongs to.
+func (r *Ring) HSet(key, field, value string) (bool, error) {
+	return r.Shard(key).HSet(key, field, value)
+}
+
+// HSetnx calls Redis.HSetnx on the shard key belongs to.
+func (r *Ring) HSetnx(key, field, value string) (bool, error) {
+	return r.Shard(key).HSetnx(key, field, value)
+}
+
+// HVals calls Redis.HVals on the shard key belongs to.
+func (r *Ring) HVals(key string) ([]string, error) {
+	return r.Shard(key).HVals(key)
+}
+
+// HScan calls Redis.HScan on the shard key belongs to.
+func (r *Ring) HScan(key string, cursor uint64, pattern string, count int) (uint64, map[string]string, error) {
+	return r.Shard(key).HScan(key, cursor, pattern, count)
+}
+
+// LIndex calls Redis.LIndex on the shard key belongs to.
+func (r *Ring) LIndex(key string, index int) ([]byte, error) {
+	return r.Shard(key).LIndex(key, index)
+}
+
+// LInsert calls Redis.LInsert on the shard key belongs to.
+func (r *Ring) LInsert(key, position, pivot, value string) (int64, error) {
+	return r.Shard(key).LInsert(key, position, pivot, value)
+}
+
+// LLen calls Redis.LLen on the shard key belongs to.
+func (r *Ring) LLen(key string) (int64, error) {
+	return r.Shard(key).LLen(key)
+}
+
+// LPop calls Redis.LPop on the shard key belongs to.
+func (r *Ring) LPop(key string) ([]byte, error) {
+	return r.Shard(key).LPop(key)
+}
+
+// LPush calls Redis.LPush on the shard key belongs to.
+func (r *Ring) LPush(key string, values ...string) (int64, error) {
+	return r.Shard(key).LPush(key, values...)
+}
+
+// LPushx calls Redis.LPushx on the shard key belongs to.
+func (r *Ring) LPushx(key string, values ...string) (int64, error) {
+	return r.Shard(key).LPushx(key, values...)
+}
+
+// LRange calls Redis.LRange on the shard key belongs to.
+func (r *Ring) LRange(key string, start, end int) ([]string, error) {
+	return r.Shard(key).LRange(key, start, end)
+}
+
+// LRem calls Redis.LRem on the shard key belongs to.
+func (r *Ring) LRem(key string, count int, value string) (int64, error) {
+	return r.Shard(key).LRem(key, count, value)
+}
+
+// LSet calls Redis.LSet on the shard key belongs to.
+func (r *Ring) LSet(key string, index int, value string) error {
+	return r.Shard(key).LSet(key, index, value)
+}
+
+// LTrim calls Redis.LTrim on the shard key belongs to.
+func (r *Ring) LTrim(key string, start, stop int) error {
+	return r.Shard(key).LTrim(key, start, stop)
+}
+
+// RPop calls Redis.RPop on the shard key belongs to.
+func (r *Ring) RPop(key string) ([]byte, error) {
+	return r.Shard(key).RPop(key)
+}
+
+// RPush calls Redis.RPush on the shard key belongs to.
+func (r *Ring) RPush(key string, values ...string) (int64, error) {
+	return r.Shard(key).RPush(key, values...)
+}
+
+// RPushx calls Redis.RPushx on the shard key belongs to.
+func (r *Ring) RPushx(key string, values ...string) (int64, error) {
+	return r.Shard(key).RPushx(key, values...)
+}
+
+// SAdd calls Redis.SAdd on the shard key belongs to.
+func (r *Ring) SAdd(key string, members ...string) (int64, error) {
+	return r.Shard(key).SAdd(key, members...)
+}
+
+// SCard calls Redis.SCard on the shard key belongs to.
+func (r *Ring) SCard(key string) (int64, error) {
+	return r.Shard(key).SCard(key)
+}
+
+// SIsMember calls Redis.SIsMember on the shard key belongs to.
+func (r *Ring) SIsMember(key, member string) (bool, error) {
+	return r.Shard(key).SIsMember(key, member)
+}
+
+// SMembers calls Redis.SMembers on the shard key belongs to.
+func (r *Ring) SMembers(key string) ([]string, error) {
+	return r.Shard(key).SMembers(key)
+}
+
+// SPop calls Redis.SPop on the shard key belongs to.
+func (r *Ring) SPop(key string) ([]byte, error) {
+	return r.Shard(key).SPop(key)
+}
+
+// SRandMember calls Redis.SRandMember on the shard key belongs to.
+func (r *Ring) SRandMember(key string) ([]byte, error) {
+	return r.Shard(key).SRandMember(key)
+}
+
+// SRandMemberCount calls Redis.SRandMemberCount on the shard key belongs to.
+func (r *Ring) SRandMemberCount(key string, count int) ([]string, error) {
+	return r.Shard(key).SRandMemberCount(key, count)
+}
+
+// SRem calls Redis.SRem on the shard key belongs to.
+func (r *Ring) SRem(key string, members ...string) (int64, error) {
+	return r.Shard(key).SRem(key, members...)
+}
+
+// SScan calls Redis.SScan on the shard key belongs to.
+func (r *Ring) SScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	return r.Shard(key).SScan(key, cursor, pattern, count)
+}
+
+// ZAdd calls Redis.ZAdd on the shard key belongs to.
+func (r *Ring) ZAdd(key string, pairs map[string]float64) (int64, error) {
+	return r.Shard(key).ZAdd(key, pairs)
+}
+
+// ZCard calls Redis.ZCard on the shard key belongs to.
+func (r *Ring) ZCard(key string) (int64, error) {
+	return r.Shard(key).ZCard(key)
+}
+
+// ZCount calls Redis.ZCount on the shard key belongs to.
+func (r *Ring) ZCount(key, min, max string) (int64, error) {
+	return r.Shard(key).ZCount(key, min, max)
+}
+
+// ZIncrBy calls Redis.ZIncrBy on the shard key belongs to.
+func (r *Ring) ZIncrBy(key string, increment float64, member string) (float64, error) {
+	return r.Shard(key).ZIncrBy(key, increment, member)
+}
+
+// ZLexCount calls Redis.ZLexCount on the shard key belongs to.
+func (r *Ring) ZLexCount(key, min, max string) (int64, error) {
+	return r.Shard(key).ZLexCount(key, min, max)
+}
+
+// ZRange calls Redis.ZRange on the shard key belongs to.
+func (r *Ring) ZRange(key string, start, stop int, withscores bool) ([]string, error) {
+	return r.Shard(key).ZRange(key, start, stop, withscores)
+}
+
+// ZRangeByLex calls Redis.ZRangeByLex on the shard key belongs to.
+func (r *Ring) ZRangeByLex(key, min, max string, limit bool, offset, count int) ([]string, error) {
+	return r.Shard(key).ZRangeByLex(key, min, max, limit, offset, count)
+}
+
+// ZRangeByScore calls Redis.ZRangeByScore on the shard key belongs to.
+func (r *Ring) ZRangeByScore(key, min, max string, withscores, limit bool, offset, count int) ([]string, error) {
+	return r.Shard(key).ZRangeByScore(key, min, max, withscores, limit, offset, count)
+}
+
+// ZRank calls Redis.ZRank on the shard key belongs to.
+func (r *Ring) ZRank(key, member string) (int64, error) {
+	return r.Shard(key).ZRank(key, member)
+}
+
+// ZRem calls Redis.ZRem on the shard key belongs to.
+func (r *Ring) ZRem(key string, members ...string) (int64, error) {
+	return r.Shard(key).ZRem(key, members...)
+}
+
+// ZRemRangeByLex calls Redis.ZRemRangeByLex on the shard key belongs to.
+func (r *Ring) ZRemRangeByLex(key, min, max string) (int64, error) {
+	return r.Shard(key).ZRemRangeByLex(key, min, max)
+}
+
+// ZRemRangeByRank calls Redis.ZRemRangeByRank on the shard key belongs to.
+func (r *Ring) ZRemRangeByRank(key string, start, stop int) (int64, error) {
+	return r.Shard(key).ZRemRangeByRank(key, start, stop)
+}
+
+// ZRemRangeByScore calls Redis.ZRemRangeByScore on the shard key belongs to.
+func (r *Ring) ZRemRangeByScore(key, min, max string) (int64, error) {
+	return r.Shard(key).ZRemRangeByScore(key, min, max)
+}
+
+// ZRevRange calls Redis.ZRevRange on the shard key belongs to.
+func (r *Ring) ZRevRange(key string, start, stop int, withscores bool) ([]string, error) {
+	return r.Shard(key).ZRevRange(key, start, stop, withscores)
+}
+
+// ZRevRangeByScore calls Redis.ZRevRangeByScore on the shard key belongs to.
+func (r *Ring) ZRevRangeByScore(key, max, min string, withscores, limit bool, offset, count int) ([]string, error) {
+	return r.Shard(key).ZRevRangeByScore(key, max, min, withscores, limit, offset, count)
+}
+
+// ZRevRank calls Redis.ZRevRank on the shard key belongs to.
+func (r *Ring) ZRevRank(key, member string) (int64, error) {
+	return r.Shard(key).ZRevRank(key, member)
+}
+
+// ZScore calls Redis.ZScore on the shard key belongs to.
+func (r *Ring) ZScore(key, member string) ([]byte, error) {
+	return r.Shard(key).ZScore(key, member)
+}
+
+// ZScan calls Redis.ZScan on the shard key belongs to.
+func (r *Ring) ZScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	return r.Shard(key).ZScan(key, cursor, pattern, count)
+}
+
+// PFAdd calls Redis.PFAdd on the shard key belongs to.
+func (r *Ring) PFAdd(key string, elements ...string) (int64, error) {
+	return r.Shard(key).PFAdd(key, elements...)
+}
@@ -0,0 +1,144 @@
+package goredis
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeStore is a fake server keeping string keys in memory.
+func newFakeStore(t *testing.T) (*fakeServer, map[string]string) {
+	var mutex sync.Mutex
+	data := make(map[string]string)
+	s := newFakeServer(t, func(args []string) interface{} {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch args[0] {
+		case "PING":
+			return fakeStatus("PONG")
+		case "SET":
+			data[args[1]] = args[2]
+			return fakeStatus("OK")
+		case "GET":
+			if value, ok := data[args[1]]; ok {
+				return value
+			}
+			return nil
+		case "MGET":
+			var values []interface{}
+			for _, key := range args[1:] {
+				if value, ok := data[key]; ok {
+					values = append(values, value)
+				} else {
+					values = append(values, nil)
+				}
+			}
+			return values
+		case "DEL":
+			n := 0
+			for _, key := range args[1:] {
+				if _, ok := data[key]; ok {
+					delete(data, key)
+					n++
+				}
+			}
+			return n
+		}
+		return errors.New("ERR unknown command")
+	})
+	return s, data
+}
+
+func TestHashTag(t *testing.T) {
+	tests := map[string]string{
+		"key":            "key",
+		"{user1}:a":      "user1",
+		"prefix{user1}b": "user1",
+		"{}key":          "{}key",
+		"{key":           "{key",
+		"a{b}{c}":        "b",
+	}
+	for key, tag := range tests {
+		if hashTag(key) != tag {
+			t.Errorf("hash tag of %s: %s", key, hashTag(key))
+		}
+	}
+}
+
+func TestRing(t *testing.T) {
+	shards := make(map[string]*DialConfig)
+	servers := make(map[string]*fakeServer)
+	for i := 0; i < 3; i++ {
+		s, _ := newFakeStore(t)
+		defer s.Close()
+		name := "shard" + strconv.Itoa(i)
+		servers[name] = s
+		shards[name] = &DialConfig{Address: s.Addr(), Timeout: time.Second}
+	}
+	ring, err := DialRing(&RingConfig{Shards: shards, CheckInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ring.Close()
+	if len(ring.Shards()) != 3 {
+		t.Fatal(ring.Shards())
+	}
+	used := make(map[string]bool)
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+		used[ring.ShardName(keys[i])] = true
+		if err := ring.SimpleSet(keys[i], strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(used) != 3 {
+		t.Errorf("keys only on %d shards", len(used))
+	}
+	if ring.ShardName("{user1}:a") != ring.ShardName("{user1}:b") {
+		t.Error("hash tag not respected")
+	}
+	values, err := ring.MGet(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, value := range values {
+		if string(value) != strconv.Itoa(i) {
+			t.Fatalf("%s: %s", keys[i], value)
+		}
+	}
+
+	dead := ring.ShardName("key0")
+	servers[dead].Close()
+	for i := 0; len(ring.Shards()) != 2; i++ {
+		if i > 100 {
+			t.Fatal("dead shard still in the ring")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ring.ShardName("key0") == dead {
+		t.Fail()
+	}
+	moved := 0
+	for _, key := range keys {
+		name := ring.ShardName(key)
+		if name == dead {
+			t.Fatal("key on dead shard")
+		}
+		if value, err := ring.Get(key); err != nil {
+			t.Error(err)
+		} else if value == nil {
+			moved++
+		}
+	}
+	if moved == 0 || moved == len(keys) {
+		t.Errorf("%d keys moved", moved)
+	}
+	if n, err := ring.Del(keys...); err != nil {
+		t.Error(err)
+	} else if int(n) != len(keys)-moved {
+		t.Fail()
+	}
+}
@@ -0,0 +1,168 @@
+package goredis
+
+import "errors"
+
+// scanner drives the cursor loop of SCAN, HSCAN, SSCAN and ZSCAN,
+// handing out the elements of the batches width at a time.
+type scanner struct {
+	redis   *Redis
+	command []interface{}
+	options []interface{}
+	width   int
+
+	cursor  uint64
+	started bool
+	batch   []string
+	current []string
+	err     error
+}
+
+func newScanner(r *Redis, command []interface{}, pattern string, count int, width int) *scanner {
+	s := &scanner{redis: r, command: command, width: width}
+	if pattern != "" {
+		s.options = append(s.options, "MATCH", pattern)
+	}
+	if count > 0 {
+		s.options = append(s.options, "COUNT", count)
+	}
+	return s
+}
+
+func (s *scanner) next() bool {
+	if s.err != nil {
+		return false
+	}
+	for len(s.batch) == 0 {
+		if s.started && s.cursor == 0 {
+			return false
+		}
+		if !s.fetch() {
+			return false
+		}
+	}
+	if len(s.batch) < s.width {
+		s.err = errors.New("scan protocol error")
+		return false
+	}
+	s.current, s.batch = s.batch[:s.width], s.batch[s.width:]
+	return true
+}
+
+func (s *scanner) fetch() bool {
+	args := packArgs(s.command, s.cursor, s.options)
+	rp, err := s.redis.ExecuteCommand(args...)
+	if err == nil {
+		s.cursor, s.batch, err = scanValue(rp)
+	}
+	if err != nil {
+		s.err = err
+		return false
+	}
+	s.started = true
+	return true
+}
+
+// ScanIterator iterates over the keys of the database with SCAN.
+// A key may be returned more than once, and the keys added or removed
+// during the iteration may or may not be returned.
+//
+//	it := r.ScanIterator("user:*", 100, "")
+//	for it.Next() {
+//		fmt.Println(it.Val())
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ScanIterator struct {
+	s *scanner
+}
+
+// ScanIterator new a *ScanIterator over the keys matching pattern, of type typ if typ is not empty.
+// pattern and count may be empty and 0.
+func (r *Redis) ScanIterator(pattern string, count int, typ string) *ScanIterator {
+	s := newScanner(r, packArgs("SCAN"), pattern, count, 1)
+	if typ != "" {
+		s.options = append(s.options, "TYPE", typ)
+	}
+	return &ScanIterator{s}
+}
+
+// Next advances to the next key, false once the iteration is complete or failed.
+func (it *ScanIterator) Next() bool { return it.s.next() }
+
+// Val returns the current key.
+func (it *ScanIterator) Val() string { return it.s.current[0] }
+
+// Err returns the error which stopped the iteration, if any.
+func (it *ScanIterator) Err() error { return it.s.err }
+
+// HScanIterator iterates over the fields of a hash with HSCAN.
+type HScanIterator struct {
+	s *scanner
+}
+
+// HScanIterator new a *HScanIterator over the fields of the hash stored at key matching pattern.
+func (r *Redis) HScanIterator(key, pattern string, count int) *HScanIterator {
+	return &HScanIterator{newScanner(r, packArgs("HSCAN", key), pattern, count, 2)}
+}
+
+// Next advances to the next field, false once the iteration is complete or failed.
+func (it *HScanIterator) Next() bool { return it.s.next() }
+
+// Val returns the current field and its value.
+func (it *HScanIterator) Val() HashField {
+	return HashField{it.s.current[0], it.s.current[1]}
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *HScanIterator) Err() error { return it.s.err }
+
+// SScanIterator iterates over the members of a set with SSCAN.
+type SScanIterator struct {
+	s *scanner
+}
+
+// SScanIterator new a *SScanIterator over the members of the set stored at key matching pattern.
+func (r *Redis) SScanIterator(key, pattern string, count int) *SScanIterator {
+	return &SScanIterator{newScanner(r, packArgs("SSCAN", key), pattern, count, 1)}
+}
+
+// Next advances to the next member, false once the iteration is complete or failed.
+func (it *SScanIterator) Next() bool { return it.s.next() }
+
+// Val returns the current member.
+func (it *SScanIterator) Val() string { return it.s.current[0] }
+
+// Err returns the error which stopped the iteration, if any.
+func (it *SScanIterator) Err() error { return it.s.err }
+
+// ZScanIterator iterates over the members of a sorted set with ZSCAN.
+type ZScanIterator struct {
+	s   *scanner
+	val Z
+}
+
+// ZScanIterator new a *ZScanIterator over the members of the sorted set stored at key matching pattern.
+func (r *Redis) ZScanIterator(key, pattern string, count int) *ZScanIterator {
+	return &ZScanIterator{s: newScanner(r, packArgs("ZSCAN", key), pattern, count, 2)}
+}
+
+// Next advances to the next member, false once the iteration is complete or failed.
+func (it *ZScanIterator) Next() bool {
+	if !it.s.next() {
+		return false
+	}
+	zs, err := zValues(it.s.current, nil)
+	if err != nil {
+		it.s.err = err
+		return false
+	}
+	it.val = zs[0]
+	return true
+}
+
+// Val returns the current member and its score.
+func (it *ZScanIterator) Val() Z { return it.val }
+
+// Err returns the error which stopped the iteration, if any.
+func (it *ZScanIterator) Err() error { return it.s.err }
@@ -0,0 +1,84 @@
+package goredis
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestScanIterator(t *testing.T) {
+	r.FlushDB()
+	for i := 0; i < 25; i++ {
+		r.Set("scan:"+strconv.Itoa(i), "value", 0, 0, false, false)
+	}
+	r.LPush("scan:list", "a")
+	r.Set("other", "value", 0, 0, false, false)
+	seen := map[string]bool{}
+	it := r.ScanIterator("scan:*", 10, "")
+	for it.Next() {
+		seen[it.Val()] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 26 || seen["other"] {
+		t.Error(len(seen))
+	}
+	it = r.ScanIterator("", 0, "list")
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Val())
+	}
+	if it.Err() != nil || len(keys) != 1 || keys[0] != "scan:list" {
+		t.Error(keys, it.Err())
+	}
+}
+
+func TestHScanIterator(t *testing.T) {
+	r.Del("key")
+	r.HMSet("key", map[string]string{"a": "1", "b": "2", "c": "3"})
+	values := map[string]string{}
+	it := r.HScanIterator("key", "", 0)
+	for it.Next() {
+		values[it.Val().Field] = it.Val().Value
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 3 || values["b"] != "2" {
+		t.Error(values)
+	}
+}
+
+func TestSScanIterator(t *testing.T) {
+	r.Del("key")
+	r.SAdd("key", "one", "two", "three")
+	n := 0
+	it := r.SScanIterator("key", "t*", 0)
+	for it.Next() {
+		n++
+	}
+	if it.Err() != nil || n != 2 {
+		t.Error(n, it.Err())
+	}
+	r.Set("key", "value", 0, 0, false, false)
+	it = r.SScanIterator("key", "", 0)
+	if it.Next() || it.Err() == nil {
+		t.Error("sscan over a string")
+	}
+}
+
+func TestZScanIterator(t *testing.T) {
+	r.Del("key")
+	r.ZAdd("key", map[string]float64{"one": 1, "two": 2.5})
+	scores := map[string]float64{}
+	it := r.ZScanIterator("key", "", 0)
+	for it.Next() {
+		scores[it.Val().Member] = it.Val().Score
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(scores) != 2 || scores["two"] != 2.5 {
+		t.Error(scores)
+	}
+}
@@ -1,5 +1,13 @@
 package goredis
 
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
 // ScriptExists returns information about the existence of the scripts in the script cache.
 // Multi-bulk reply The command returns an array of integers
 // that correspond to the specified SHA1 digest arguments.
@@ -15,12 +23,19 @@ func (r *Redis) ScriptExists(scripts ...string) ([]bool, error) {
 
 // ScriptFlush flush the Lua scripts cache.
 // Please refer to the EVAL documentation for detailed information about Redis Lua scripting.
+// The scripts of the ScriptRegistry preloaded with PreloadScripts are loaded again.
 func (r *Redis) ScriptFlush() error {
 	rp, err := r.ExecuteCommand("SCRIPT", "FLUSH")
 	if err != nil {
 		return err
 	}
-	return rp.OKValue()
+	if err := rp.OKValue(); err != nil {
+		return err
+	}
+	if r.scripts != nil {
+		return r.scripts.Load(r)
+	}
+	return nil
 }
 
 // ScriptKill kills the currently executing Lua script,
@@ -53,14 +68,198 @@ func (r *Redis) ScriptLoad(script string) (string, error) {
 // (starting from the third argument) that represent Redis key names.
 // This arguments can be accessed by Lua using the KEYS global variable
 // in the form of a one-based array (so KEYS[1], KEYS[2], ...).
-func (r *Redis) Eval(script string, keys []string, args []string) (*Reply, error) {
-	cmds := packArgs("EVAL", script, len(keys), keys, args)
+// args are strings, []byte, numbers, or []string and []interface{} which are expanded.
+func (r *Redis) Eval(script string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("EVAL", script, len(keys), keys, scriptArgs(args))
 	return r.ExecuteCommand(cmds...)
 }
 
 // EvalSha evaluates a script cached on the server side by its SHA1 digest.
 // Scripts are cached on the server side using the SCRIPT LOAD command.
-func (r *Redis) EvalSha(sha1 string, keys []string, args []string) (*Reply, error) {
-	cmds := packArgs("EVALSHA", sha1, len(keys), keys, args)
+func (r *Redis) EvalSha(sha1 string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("EVALSHA", sha1, len(keys), keys, scriptArgs(args))
+	return r.ExecuteCommand(cmds...)
+}
+
+// EvalRO is the read-only variant of Eval, the script can not modify the dataset
+// and can run on replicas.
+// Available since Redis 7.0.
+func (r *Redis) EvalRO(script string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("EVAL_RO", script, len(keys), keys, scriptArgs(args))
 	return r.ExecuteCommand(cmds...)
 }
+
+// EvalShaRO is the read-only variant of EvalSha.
+// Available since Redis 7.0.
+func (r *Redis) EvalShaRO(sha1 string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := packArgs("EVALSHA_RO", sha1, len(keys), keys, scriptArgs(args))
+	return r.ExecuteCommand(cmds...)
+}
+
+// scriptArgs expands the []string and []interface{} of args and drops the nils,
+// keeping a []byte as one argument.
+func scriptArgs(args []interface{}) []interface{} {
+	var result []interface{}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case nil:
+		case []string:
+			for _, s := range arg {
+				result = append(result, s)
+			}
+		case []interface{}:
+			result = append(result, scriptArgs(arg)...)
+		default:
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+// Script is a Lua script run by its SHA1 digest with EVALSHA,
+// falling back to EVAL when the server does not have it in its script cache yet.
+//
+//	var incrBy = goredis.NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+//	rp, err := incrBy.Eval(r, []string{"counter"}, 2)
+//
+// The script may declare the numbers of keys and args it takes in comments on its first lines,
+// calls with other numbers fail without reaching the server:
+//
+//	-- keys: 1
+//	-- args: 1
+//	return redis.call('INCRBY', KEYS[1], ARGV[1])
+type Script struct {
+	src  string
+	hash string
+	keys int
+	args int
+}
+
+// NewScript new a *Script from the Lua source src.
+// A malformed header is ignored, the counts are then not checked.
+func NewScript(src string) *Script {
+	s, err := parseScript(src)
+	if err != nil {
+		s.keys, s.args = -1, -1
+	}
+	return s
+}
+
+// parseScript new a *Script from src, and reads the counts of its header, -1 if not declared.
+func parseScript(src string) (*Script, error) {
+	sum := sha1.Sum([]byte(src))
+	s := &Script{src: src, hash: hex.EncodeToString(sum[:]), keys: -1, args: -1}
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "--") {
+			break
+		}
+		parts := strings.SplitN(strings.TrimSpace(line[2:]), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		var count *int
+		switch strings.ToLower(strings.TrimSpace(parts[0])) {
+		case "keys":
+			count = &s.keys
+		case "args":
+			count = &s.args
+		default:
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || n < 0 {
+			return s, errors.New("script header: invalid count " + strconv.Quote(line))
+		}
+		*count = n
+	}
+	return s, nil
+}
+
+// Hash returns the SHA1 digest of the script.
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Source returns the Lua source of the script.
+func (s *Script) Source() string {
+	return s.src
+}
+
+// Load loads the script into the script cache of r.
+func (s *Script) Load(r *Redis) error {
+	_, err := r.ScriptLoad(s.src)
+	return err
+}
+
+// Exists returns true if the script is in the script cache of r.
+func (s *Script) Exists(r *Redis) (bool, error) {
+	bs, err := r.ScriptExists(s.hash)
+	if err != nil {
+		return false, err
+	}
+	return len(bs) == 1 && bs[0], nil
+}
+
+// check returns an error if the numbers of keys and args differ from the header of the script.
+func (s *Script) check(keys []string, args []interface{}) error {
+	if s.keys >= 0 && len(keys) != s.keys {
+		return errors.New("script " + s.hash + " takes " + strconv.Itoa(s.keys) + " keys, got " + strconv.Itoa(len(keys)))
+	}
+	if n := len(scriptArgs(args)); s.args >= 0 && n != s.args {
+		return errors.New("script " + s.hash + " takes " + strconv.Itoa(s.args) + " args, got " + strconv.Itoa(n))
+	}
+	return nil
+}
+
+// Eval runs the script with EVALSHA, and with EVAL if the server replies NOSCRIPT,
+// which caches the script for the next calls.
+func (s *Script) Eval(r *Redis, keys []string, args ...interface{}) (*Reply, error) {
+	if err := s.check(keys, args); err != nil {
+		return nil, err
+	}
+	rp, err := r.EvalSha(s.hash, keys, args...)
+	if err == nil && isNoScript(rp) {
+		return r.Eval(s.src, keys, args...)
+	}
+	return rp, err
+}
+
+// EvalRO is Eval with EVALSHA_RO and EVAL_RO.
+// Available since Redis 7.0.
+func (s *Script) EvalRO(r *Redis, keys []string, args ...interface{}) (*Reply, error) {
+	if err := s.check(keys, args); err != nil {
+		return nil, err
+	}
+	rp, err := r.EvalShaRO(s.hash, keys, args...)
+	if err == nil && isNoScript(rp) {
+		return r.EvalRO(s.src, keys, args...)
+	}
+	return rp, err
+}
+
+// Send sends the script into the pipeline p.
+// The reply is only read with the replies of the pipeline, too late to fall back on NOSCRIPT,
+// so the script is sent in full with EVAL.
+func (s *Script) Send(p *Pipelined, keys []string, args ...interface{}) error {
+	if err := s.check(keys, args); err != nil {
+		return err
+	}
+	cmds := packArgs("EVAL", s.src, len(keys), keys, scriptArgs(args))
+	return p.Command(cmds...)
+}
+
+// Queue queues the script into the transaction t.
+// Like Send, the script is queued in full with EVAL,
+// an EVALSHA failing with NOSCRIPT would only be known once the transaction is executed.
+func (s *Script) Queue(t *Transaction, keys []string, args ...interface{}) error {
+	if err := s.check(keys, args); err != nil {
+		return err
+	}
+	cmds := packArgs("EVAL", s.src, len(keys), keys, scriptArgs(args))
+	return t.queue(cmds)
+}
+
+func isNoScript(rp *Reply) bool {
+	return rp.Type == ErrorReply && strings.HasPrefix(rp.Error, "NOSCRIPT")
+}
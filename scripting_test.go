@@ -80,3 +80,89 @@ func TestScriptKill(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestEvalArgs(t *testing.T) {
+	rp, err := r.Eval("return {ARGV[1], ARGV[2], ARGV[3]}", nil, "a", 2, []byte("c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, err := rp.ListValue(); err != nil || len(l) != 3 || l[1] != "2" || l[2] != "c" {
+		t.Error(l, err)
+	}
+	rp, err = r.Eval("return #ARGV", nil, []interface{}{"a", 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := rp.IntegerValue(); n != 2 {
+		t.Error(n)
+	}
+}
+
+func TestScript(t *testing.T) {
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+	r.ScriptFlush()
+	r.Del("counter")
+	if exists, err := script.Exists(r); err != nil || exists {
+		t.Error(exists, err)
+	}
+	rp, err := script.Eval(r, []string{"counter"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := rp.IntegerValue(); err != nil || n != 2 {
+		t.Error(n, err)
+	}
+	if err := script.Load(r); err != nil {
+		t.Fatal(err)
+	}
+	sha1, _ := r.ScriptLoad(script.Source())
+	if sha1 != script.Hash() {
+		t.Error(sha1, script.Hash())
+	}
+	if rp, err := script.Eval(r, []string{"counter"}, 3); err != nil || rp.Integer != 5 {
+		t.Error(rp, err)
+	}
+}
+
+func TestScriptPipelineTransaction(t *testing.T) {
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])")
+	r.ScriptFlush()
+	r.Del("counter")
+	p, err := r.Pipelining()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	script.Send(p, []string{"counter"}, 1)
+	script.Send(p, []string{"counter"}, 1)
+	rps, err := p.ReceiveAll()
+	if err != nil || len(rps) != 2 || rps[1].Integer != 2 {
+		t.Fatal(rps, err)
+	}
+	r.ScriptFlush()
+	transaction, err := r.Transaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transaction.Close()
+	if err := script.Queue(transaction, []string{"counter"}, 3); err != nil {
+		t.Fatal(err)
+	}
+	rps, err = transaction.Exec()
+	if err != nil || len(rps) != 1 || rps[0].Integer != 5 {
+		t.Error(rps, err)
+	}
+}
+
+func TestScriptEvalRO(t *testing.T) {
+	script := NewScript("return redis.call('GET', KEYS[1])")
+	r.ScriptFlush()
+	r.Set("key", "value", 0, 0, false, false)
+	rp, err := script.EvalRO(r, []string{"key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := rp.StringValue(); err != nil || s != "value" {
+		t.Error(s, err)
+	}
+}
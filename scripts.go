@@ -0,0 +1,120 @@
+package goredis
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ScriptRegistry is a set of Lua scripts by name, loaded from the .lua files of a fs.FS,
+// so the scripts can be embedded in the binary:
+//
+//	//go:embed lua
+//	var luaFS embed.FS
+//
+//	scripts, err := goredis.LoadScripts(luaFS)
+//	rp, err := scripts.Eval(r, "lua/incrby", []string{"counter"}, 2)
+//
+// The files may declare the numbers of keys and args they take, see Script.
+type ScriptRegistry struct {
+	scripts map[string]*Script
+}
+
+// LoadScripts reads every .lua file of fsys into a *ScriptRegistry.
+// A script is named by its path without the .lua extension, like lua/incrby.
+func LoadScripts(fsys fs.FS) (*ScriptRegistry, error) {
+	reg := &ScriptRegistry{scripts: make(map[string]*Script)}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != ".lua" {
+			return err
+		}
+		src, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		s, err := parseScript(string(src))
+		if err != nil {
+			return errors.New(name + ": " + err.Error())
+		}
+		reg.scripts[strings.TrimSuffix(name, ".lua")] = s
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// Get returns the script name, nil if there is no such script.
+func (reg *ScriptRegistry) Get(name string) *Script {
+	return reg.scripts[name]
+}
+
+// Names returns the names of the scripts, sorted.
+func (reg *ScriptRegistry) Names() []string {
+	names := make([]string, 0, len(reg.scripts))
+	for name := range reg.scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Eval runs the script name with Script.Eval.
+func (reg *ScriptRegistry) Eval(r *Redis, name string, keys []string, args ...interface{}) (*Reply, error) {
+	s := reg.scripts[name]
+	if s == nil {
+		return nil, errors.New("no script " + name)
+	}
+	return s.Eval(r, keys, args...)
+}
+
+// EvalRO runs the script name with Script.EvalRO.
+func (reg *ScriptRegistry) EvalRO(r *Redis, name string, keys []string, args ...interface{}) (*Reply, error) {
+	s := reg.scripts[name]
+	if s == nil {
+		return nil, errors.New("no script " + name)
+	}
+	return s.EvalRO(r, keys, args...)
+}
+
+// Load loads all the scripts into the script cache of r.
+func (reg *ScriptRegistry) Load(r *Redis) error {
+	for _, s := range reg.scripts {
+		if err := s.Load(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadConnection loads all the scripts in one pipeline on c.
+func (reg *ScriptRegistry) loadConnection(c *connection) error {
+	for _, s := range reg.scripts {
+		if err := c.SendCommand("SCRIPT", "LOAD", s.src); err != nil {
+			return err
+		}
+	}
+	var first error
+	for range reg.scripts {
+		rp, err := c.RecvReply()
+		if err != nil {
+			return err
+		}
+		if rp.Type == ErrorReply && first == nil {
+			first = errors.New(rp.Error)
+		}
+	}
+	return first
+}
+
+// PreloadScripts loads the scripts of reg now, then on every new connection of the pool,
+// which follows a restart or a failover of the server, and after ScriptFlush,
+// so that EVALSHA does not have to fall back to EVAL.
+// It should be called before r is shared between goroutines.
+func (r *Redis) PreloadScripts(reg *ScriptRegistry) error {
+	r.scripts = reg
+	return reg.Load(r)
+}
@@ -0,0 +1,78 @@
+package goredis
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+var testScripts = fstest.MapFS{
+	"lua/incrby.lua": {Data: []byte("-- Increments KEYS[1] by ARGV[1].\n-- keys: 1\n-- args: 1\nreturn redis.call('INCRBY', KEYS[1], ARGV[1])\n")},
+	"lua/get.lua":    {Data: []byte("return redis.call('GET', KEYS[1])\n")},
+	"README.md":      {Data: []byte("not a script")},
+}
+
+func TestLoadScripts(t *testing.T) {
+	scripts, err := LoadScripts(testScripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := scripts.Names(); len(names) != 2 || names[0] != "lua/get" || names[1] != "lua/incrby" {
+		t.Error(names)
+	}
+	if scripts.Get("README") != nil || scripts.Get("lua/get") == nil {
+		t.Error("unexpected scripts")
+	}
+	r.Del("counter")
+	rp, err := scripts.Eval(r, "lua/incrby", []string{"counter"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := rp.IntegerValue(); n != 2 {
+		t.Error(n)
+	}
+	if _, err := scripts.Eval(r, "lua/incrby", []string{"counter"}); err == nil {
+		t.Error("missing arg not detected")
+	}
+	if _, err := scripts.Eval(r, "lua/incrby", nil, 1); err == nil {
+		t.Error("missing key not detected")
+	}
+	if _, err := scripts.Eval(r, "lua/nothing", nil); err == nil {
+		t.Error("unknown script")
+	}
+	bad := fstest.MapFS{"bad.lua": {Data: []byte("-- keys: two\nreturn 1")}}
+	if _, err := LoadScripts(bad); err == nil {
+		t.Error("invalid header accepted")
+	}
+}
+
+func TestPreloadScripts(t *testing.T) {
+	scripts, err := LoadScripts(testScripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Dial(&DialConfig{network, address, db, password, timeout, maxidle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	incrby := scripts.Get("lua/incrby")
+	r.ScriptFlush()
+	if err := client.PreloadScripts(scripts); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := incrby.Exists(r); !exists {
+		t.Error("script not loaded")
+	}
+	if err := client.ScriptFlush(); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := incrby.Exists(r); !exists {
+		t.Error("script not loaded again after flush")
+	}
+	// A new connection, like after a restart of the server, loads the scripts.
+	r.ExecuteCommand("SCRIPT", "FLUSH")
+	client.pool.Reset(client.dialConnection)
+	if exists, _ := incrby.Exists(client); !exists {
+		t.Error("script not loaded on a new connection")
+	}
+}
@@ -0,0 +1,374 @@
+package goredis
+
+import (
+	"container/list"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SentinelGetMasterAddrByName returns the address(host:port) of the master with that name,
+// as known by the sentinel the client is connected to.
+// If a failover is in progress or terminated successfully for this master
+// it returns the address of the promoted replica.
+func (r *Redis) SentinelGetMasterAddrByName(name string) (string, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "GET-MASTER-ADDR-BY-NAME", name)
+	if err != nil {
+		return "", err
+	}
+	addr, err := rp.ListValue()
+	if err != nil {
+		return "", err
+	}
+	if len(addr) != 2 {
+		return "", errors.New("sentinel: no master named " + name)
+	}
+	return net.JoinHostPort(addr[0], addr[1]), nil
+}
+
+// SentinelMasters shows a list of monitored masters and their state.
+func (r *Redis) SentinelMasters() ([]map[string]string, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "MASTERS")
+	if err != nil {
+		return nil, err
+	}
+	return hashArrayValue(rp)
+}
+
+// SentinelMaster shows the state and info of the specified master.
+func (r *Redis) SentinelMaster(name string) (map[string]string, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "MASTER", name)
+	if err != nil {
+		return nil, err
+	}
+	return rp.HashValue()
+}
+
+// SentinelReplicas shows a list of replicas for this master, and their state.
+// Available since Redis 5.0, older sentinels only know SENTINEL SLAVES.
+func (r *Redis) SentinelReplicas(name string) ([]map[string]string, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "REPLICAS", name)
+	if err != nil {
+		return nil, err
+	}
+	return hashArrayValue(rp)
+}
+
+// SentinelSentinels shows a list of sentinel instances for this master, and their state.
+func (r *Redis) SentinelSentinels(name string) ([]map[string]string, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "SENTINELS", name)
+	if err != nil {
+		return nil, err
+	}
+	return hashArrayValue(rp)
+}
+
+// SentinelReset resets all the masters with matching name.
+// The pattern argument is a glob-style pattern.
+// Integer reply: the number of masters that were reset.
+func (r *Redis) SentinelReset(pattern string) (int64, error) {
+	rp, err := r.ExecuteCommand("SENTINEL", "RESET", pattern)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// SentinelFailover forces a failover as if the master was not reachable,
+// and without asking for agreement to other sentinels.
+func (r *Redis) SentinelFailover(name string) error {
+	rp, err := r.ExecuteCommand("SENTINEL", "FAILOVER", name)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+func hashArrayValue(rp *Reply) ([]map[string]string, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]string, 0, len(multi))
+	for _, subrp := range multi {
+		hash, err := subrp.HashValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hash)
+	}
+	return result, nil
+}
+
+// SentinelConfig is the parameters used to find the master through sentinels.
+// Password is used for the master, SentinelPassword for the sentinels.
+type SentinelConfig struct {
+	MasterName       string
+	Sentinels        []string
+	Network          string
+	Database         int
+	Password         string
+	SentinelPassword string
+	Timeout          time.Duration
+	MaxIdle          int
+}
+
+// FailoverClient is a redis client which always talks to the current master
+// of a set of servers monitored by Redis Sentinel.
+// It subscribes to +switch-master on one of the sentinels,
+// and when the master changes all pooled connections to the old master are dropped.
+type FailoverClient struct {
+	*Redis
+
+	masterName       string
+	sentinelPassword string
+
+	mutex     sync.Mutex
+	sentinels []string
+	master    string
+	pubsub    *PubSub
+	closed    bool
+}
+
+// DialSentinel asks the sentinels for the address of cfg.MasterName,
+// and returns a client connected to that master.
+func DialSentinel(cfg *SentinelConfig) (*FailoverClient, error) {
+	if cfg == nil || cfg.MasterName == "" || len(cfg.Sentinels) == 0 {
+		return nil, errors.New("sentinel: master name and sentinel addresses are required")
+	}
+	if cfg.Network == "" {
+		cfg.Network = DefaultNetwork
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxIdle == 0 {
+		cfg.MaxIdle = DefaultMaxIdle
+	}
+	f := &FailoverClient{
+		Redis: &Redis{
+			network:  cfg.Network,
+			db:       cfg.Database,
+			password: cfg.Password,
+			timeout:  cfg.Timeout,
+		},
+		masterName:       cfg.MasterName,
+		sentinelPassword: cfg.SentinelPassword,
+		sentinels:        append([]string(nil), cfg.Sentinels...),
+	}
+	master, err := f.discover()
+	if err != nil {
+		return nil, err
+	}
+	f.master = master
+	f.pool = &connPool{
+		MaxIdle: cfg.MaxIdle,
+		Dial:    f.dialMaster,
+		idle:    list.New(),
+	}
+	conn, err := f.dialMaster()
+	if err != nil {
+		return nil, err
+	}
+	f.pool.Put(conn)
+	go f.watch()
+	return f, nil
+}
+
+// Master returns the address of the master currently in use.
+func (f *FailoverClient) Master() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.master
+}
+
+// Close stops following the sentinels and closes the connection pool.
+func (f *FailoverClient) Close() {
+	f.mutex.Lock()
+	f.closed = true
+	if f.pubsub != nil {
+		f.pubsub.Close()
+	}
+	f.mutex.Unlock()
+	f.ClosePool()
+}
+
+// Replicas asks the sentinels for the addresses of the replicas of the master
+// which are not flagged as down or disconnected.
+// It can be used as ReplicaConfig.Replicas.
+func (f *FailoverClient) Replicas() ([]string, error) {
+	f.mutex.Lock()
+	sentinels := append([]string(nil), f.sentinels...)
+	f.mutex.Unlock()
+	err := errors.New("sentinel: no sentinel available")
+	for _, address := range sentinels {
+		sentinel, e := f.dialSentinel(address)
+		if e != nil {
+			err = e
+			continue
+		}
+		replicas, e := sentinel.SentinelReplicas(f.masterName)
+		sentinel.ClosePool()
+		if e != nil {
+			err = e
+			continue
+		}
+		var addresses []string
+		for _, replica := range replicas {
+			flags := replica["flags"]
+			if strings.Contains(flags, "s_down") || strings.Contains(flags, "o_down") ||
+				strings.Contains(flags, "disconnected") {
+				continue
+			}
+			addresses = append(addresses, net.JoinHostPort(replica["ip"], replica["port"]))
+		}
+		return addresses, nil
+	}
+	return nil, err
+}
+
+func (f *FailoverClient) dialSentinel(address string) (*Redis, error) {
+	return Dial(&DialConfig{
+		Network:  f.network,
+		Address:  address,
+		Password: f.sentinelPassword,
+		Timeout:  f.timeout,
+		MaxIdle:  1,
+	})
+}
+
+// discover asks the sentinels in order for the master address,
+// the first sentinel which answers is moved to the front of the list.
+func (f *FailoverClient) discover() (string, error) {
+	f.mutex.Lock()
+	sentinels := append([]string(nil), f.sentinels...)
+	f.mutex.Unlock()
+	err := errors.New("sentinel: no sentinel available")
+	for i, address := range sentinels {
+		sentinel, e := f.dialSentinel(address)
+		if e != nil {
+			err = e
+			continue
+		}
+		master, e := sentinel.SentinelGetMasterAddrByName(f.masterName)
+		sentinel.ClosePool()
+		if e != nil {
+			err = e
+			continue
+		}
+		f.mutex.Lock()
+		f.sentinels = append([]string{address}, append(sentinels[:i:i], sentinels[i+1:]...)...)
+		f.mutex.Unlock()
+		return master, nil
+	}
+	return "", err
+}
+
+// dialMaster dials the current master and verifies it still acts as a master,
+// a demoted master must not receive writes.
+func (f *FailoverClient) dialMaster() (*connection, error) {
+	f.mutex.Lock()
+	master := f.master
+	f.mutex.Unlock()
+	c, err := f.dialAddress(master)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SendCommand("ROLE"); err != nil {
+		c.Conn.Close()
+		return nil, err
+	}
+	rp, err := c.RecvReply()
+	if err != nil {
+		c.Conn.Close()
+		return nil, err
+	}
+	role, err := roleValue(rp)
+	if err != nil {
+		c.Conn.Close()
+		return nil, err
+	}
+	if role.Role != "master" {
+		c.Conn.Close()
+		return nil, errors.New("sentinel: " + master + " is not a master")
+	}
+	return c, nil
+}
+
+func (f *FailoverClient) switchMaster(master string) {
+	f.mutex.Lock()
+	if f.closed || master == f.master {
+		f.mutex.Unlock()
+		return
+	}
+	f.master = master
+	f.mutex.Unlock()
+	f.pool.Reset(f.dialMaster)
+}
+
+func (f *FailoverClient) isClosed() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.closed
+}
+
+// watch keeps a subscription to +switch-master on one of the sentinels,
+// trying the next sentinel whenever the current one goes away.
+func (f *FailoverClient) watch() {
+	for !f.isClosed() {
+		f.mutex.Lock()
+		sentinels := append([]string(nil), f.sentinels...)
+		f.mutex.Unlock()
+		for _, address := range sentinels {
+			f.listen(address)
+			if f.isClosed() {
+				return
+			}
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (f *FailoverClient) listen(address string) error {
+	sentinel, err := f.dialSentinel(address)
+	if err != nil {
+		return err
+	}
+	defer sentinel.ClosePool()
+	sub, err := sentinel.PubSub()
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	f.mutex.Lock()
+	if f.closed {
+		f.mutex.Unlock()
+		return nil
+	}
+	f.pubsub = sub
+	f.mutex.Unlock()
+	if err := sub.Subscribe("+switch-master"); err != nil {
+		return err
+	}
+	// The master may have changed while nobody was listening.
+	if master, err := sentinel.SentinelGetMasterAddrByName(f.masterName); err == nil {
+		f.switchMaster(master)
+	}
+	for {
+		message, err := sub.Receive()
+		if err != nil {
+			return err
+		}
+		if message[0] != "message" {
+			continue
+		}
+		// <master name> <oldip> <oldport> <newip> <newport>
+		fields := strings.Fields(message[2])
+		if len(fields) != 5 || fields[0] != f.masterName {
+			continue
+		}
+		f.switchMaster(net.JoinHostPort(fields[3], fields[4]))
+	}
+}
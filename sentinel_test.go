@@ -0,0 +1,146 @@
+package goredis
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newFakeMaster(t *testing.T, role, value string) *fakeServer {
+	return newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "ROLE":
+			if role == "slave" {
+				return []interface{}{role, "127.0.0.1", 6379, "connected", 0}
+			}
+			return []interface{}{role, 0, []interface{}{}}
+		case "PING":
+			return fakeStatus("PONG")
+		case "GET":
+			return value
+		}
+		return errors.New("ERR unknown command")
+	})
+}
+
+type fakeSentinel struct {
+	*fakeServer
+	mutex  sync.Mutex
+	master string
+}
+
+func newFakeSentinel(t *testing.T, master string) *fakeSentinel {
+	s := &fakeSentinel{master: master}
+	s.fakeServer = newFakeServer(t, func(args []string) interface{} {
+		if len(args) == 3 && args[0] == "SENTINEL" && args[1] == "GET-MASTER-ADDR-BY-NAME" {
+			if args[2] != "mymaster" {
+				return []interface{}(nil)
+			}
+			s.mutex.Lock()
+			host, port, _ := net.SplitHostPort(s.master)
+			s.mutex.Unlock()
+			return []string{host, port}
+		}
+		if len(args) == 2 && args[0] == "SENTINEL" && args[1] == "MASTERS" {
+			return []interface{}{[]string{"name", "mymaster", "flags", "master"}}
+		}
+		return errors.New("ERR unknown command")
+	})
+	return s
+}
+
+func (s *fakeSentinel) failover(master string) {
+	s.mutex.Lock()
+	old := s.master
+	s.master = master
+	s.mutex.Unlock()
+	oldHost, oldPort, _ := net.SplitHostPort(old)
+	host, port, _ := net.SplitHostPort(master)
+	s.Publish("+switch-master", "mymaster "+oldHost+" "+oldPort+" "+host+" "+port)
+}
+
+func TestSentinelGetMasterAddrByName(t *testing.T) {
+	s := newFakeSentinel(t, "127.0.0.1:6380")
+	defer s.Close()
+	sentinel, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sentinel.ClosePool()
+	if addr, err := sentinel.SentinelGetMasterAddrByName("mymaster"); err != nil {
+		t.Error(err)
+	} else if addr != "127.0.0.1:6380" {
+		t.Fail()
+	}
+	if _, err := sentinel.SentinelGetMasterAddrByName("unknown"); err == nil {
+		t.Fail()
+	}
+}
+
+func TestSentinelMasters(t *testing.T) {
+	s := newFakeSentinel(t, "127.0.0.1:6380")
+	defer s.Close()
+	sentinel, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sentinel.ClosePool()
+	if masters, err := sentinel.SentinelMasters(); err != nil {
+		t.Error(err)
+	} else if len(masters) != 1 || masters[0]["name"] != "mymaster" {
+		t.Fail()
+	}
+}
+
+func TestDialSentinel(t *testing.T) {
+	a := newFakeMaster(t, "master", "a")
+	defer a.Close()
+	b := newFakeMaster(t, "master", "b")
+	defer b.Close()
+	s := newFakeSentinel(t, a.Addr())
+	defer s.Close()
+	client, err := DialSentinel(&SentinelConfig{
+		MasterName: "mymaster",
+		Sentinels:  []string{"127.0.0.1:1", s.Addr()},
+		Timeout:    time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if value, err := client.Get("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "a" {
+		t.Fail()
+	}
+	for i := 0; s.Subscribers("+switch-master") == 0; i++ {
+		if i > 100 {
+			t.Fatal("client did not subscribe to +switch-master")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.failover(b.Addr())
+	for i := 0; client.Master() != b.Addr(); i++ {
+		if i > 100 {
+			t.Fatal("client did not switch master")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if value, err := client.Get("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "b" {
+		t.Fail()
+	}
+}
+
+func TestDialSentinelNotMaster(t *testing.T) {
+	replica := newFakeMaster(t, "slave", "a")
+	defer replica.Close()
+	s := newFakeSentinel(t, replica.Addr())
+	defer s.Close()
+	if _, err := DialSentinel(&SentinelConfig{MasterName: "mymaster", Sentinels: []string{s.Addr()}}); err == nil {
+		t.Fail()
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // BgRewriteAof Instruct Redis to start an Append Only File rewrite process.
@@ -37,17 +39,156 @@ func (r *Redis) ClientKill(ip string, port int) error {
 	return rp.OKValue()
 }
 
-// ClientList returns information and statistics
-// about the client connections server in a mostly human readable format.
-// Bulk reply: a unique string, formatted as follows:
-// One client connection per line (separated by LF)
-// Each line is composed of a succession of property=value fields separated by a space character.
-func (r *Redis) ClientList() (string, error) {
+// ClientKillArgs is the filters of ClientKillWithArgs, a client is killed if it matches all of them.
+// Type is normal, master, replica or pubsub.
+// Addr and LAddr are the ip:port of the client and of the server side of its connection.
+// SkipMe is ClientKillSkipMe (the default) or ClientKillKillMe, to kill the calling connection too.
+// MaxAge kills the clients connected for longer than it, in seconds (Redis 7.4).
+type ClientKillArgs struct {
+	ID     int64
+	Type   string
+	User   string
+	Addr   string
+	LAddr  string
+	SkipMe string
+	MaxAge int64
+}
+
+// Values of ClientKillArgs.SkipMe.
+const (
+	ClientKillSkipMe = "yes"
+	ClientKillKillMe = "no"
+)
+
+// ClientKillWithArgs closes the connections of the clients matching args.
+// args must set at least one filter other than SkipMe, else it is an error,
+// rather than killing all the other clients.
+// Integer reply: the number of clients killed.
+// CLIENT KILL [ID client-id] [TYPE type] [USER username] [ADDR ip:port] [LADDR ip:port] [SKIPME yes/no] [MAXAGE maxage]
+func (r *Redis) ClientKillWithArgs(args *ClientKillArgs) (int64, error) {
+	if args == nil || (args.ID <= 0 && args.Type == "" && args.User == "" &&
+		args.Addr == "" && args.LAddr == "" && args.MaxAge <= 0) {
+		return 0, errors.New("client kill: no filter")
+	}
+	cmds := packArgs("CLIENT", "KILL")
+	if args.ID > 0 {
+		cmds = append(cmds, "ID", args.ID)
+	}
+	if args.Type != "" {
+		cmds = append(cmds, "TYPE", args.Type)
+	}
+	if args.User != "" {
+		cmds = append(cmds, "USER", args.User)
+	}
+	if args.Addr != "" {
+		cmds = append(cmds, "ADDR", args.Addr)
+	}
+	if args.LAddr != "" {
+		cmds = append(cmds, "LADDR", args.LAddr)
+	}
+	if args.SkipMe != "" {
+		cmds = append(cmds, "SKIPME", args.SkipMe)
+	}
+	if args.MaxAge > 0 {
+		cmds = append(cmds, "MAXAGE", args.MaxAge)
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ClientInfo is a client connection of CLIENT LIST and CLIENT INFO.
+// Fields holds all the property=value fields of the client, like the ones of newer servers.
+type ClientInfo struct {
+	ID       int64         `client:"id"`
+	Addr     string        `client:"addr"`
+	LAddr    string        `client:"laddr"`
+	FD       int64         `client:"fd"`
+	Name     string        `client:"name"`
+	Age      time.Duration `client:"age"`
+	Idle     time.Duration `client:"idle"`
+	Flags    string        `client:"flags"`
+	DB       int64         `client:"db"`
+	Sub      int64         `client:"sub"`
+	PSub     int64         `client:"psub"`
+	Multi    int64         `client:"multi"`
+	Watch    int64         `client:"watch"`
+	QBuf     int64         `client:"qbuf"`
+	QBufFree int64         `client:"qbuf-free"`
+	ArgvMem  int64         `client:"argv-mem"`
+	MultiMem int64         `client:"multi-mem"`
+	OBL      int64         `client:"obl"`
+	OLL      int64         `client:"oll"`
+	OMem     int64         `client:"omem"`
+	TotMem   int64         `client:"tot-mem"`
+	Events   string        `client:"events"`
+	Cmd      string        `client:"cmd"`
+	User     string        `client:"user"`
+	Redir    int64         `client:"redir"`
+	Resp     int64         `client:"resp"`
+	LibName  string        `client:"lib-name"`
+	LibVer   string        `client:"lib-ver"`
+	Fields   map[string]string
+}
+
+// clientInfoValue parses a line of property=value fields separated by a space character.
+func clientInfoValue(line string) ClientInfo {
+	fields := make(map[string]string)
+	for _, pair := range strings.Fields(line) {
+		if i := strings.IndexByte(pair, '='); i > 0 {
+			fields[pair[:i]] = pair[i+1:]
+		}
+	}
+	info := ClientInfo{Fields: fields}
+	fillTagged(&info, "client", fields)
+	return info
+}
+
+// ClientList returns information and statistics about the client connections server.
+// Each line of the reply, one per client, is parsed into a ClientInfo.
+func (r *Redis) ClientList() ([]ClientInfo, error) {
 	rp, err := r.ExecuteCommand("CLIENT", "LIST")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return rp.StringValue()
+	list, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	var clients []ClientInfo
+	for _, line := range strings.Split(list, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			clients = append(clients, clientInfoValue(line))
+		}
+	}
+	return clients, nil
+}
+
+// ClientInfo returns the information of the current connection, like ClientList.
+// Available since Redis 6.2.
+func (r *Redis) ClientInfo() (*ClientInfo, error) {
+	rp, err := r.ExecuteCommand("CLIENT", "INFO")
+	if err != nil {
+		return nil, err
+	}
+	line, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	info := clientInfoValue(line)
+	return &info, nil
+}
+
+// ClientID returns the unique ID of the current connection.
+// Available since Redis 5.0.
+func (r *Redis) ClientID() (int64, error) {
+	rp, err := r.ExecuteCommand("CLIENT", "ID")
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
 }
 
 // ClientGetName returns the name of the current connection as set by CLIENT SETNAME.
@@ -61,7 +202,7 @@ func (r *Redis) ClientGetName() ([]byte, error) {
 	return rp.BytesValue()
 }
 
-// ClientPause stops the server processing commands from clients for some time.
+// ClientPause stops the server processing commands from clients for some time, in milliseconds.
 func (r *Redis) ClientPause(timeout uint64) error {
 	rp, err := r.ExecuteCommand("CLIENT", "PAUSE", timeout)
 	if err != nil {
@@ -70,6 +211,67 @@ func (r *Redis) ClientPause(timeout uint64) error {
 	return rp.OKValue()
 }
 
+// ClientPauseWrite is ClientPause only for the commands which may write,
+// the read-only commands are still processed.
+// Available since Redis 6.2.
+func (r *Redis) ClientPauseWrite(timeout uint64) error {
+	rp, err := r.ExecuteCommand("CLIENT", "PAUSE", timeout, "WRITE")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClientUnpause resumes the processing of the clients paused by ClientPause.
+// Available since Redis 6.2.
+func (r *Redis) ClientUnpause() error {
+	rp, err := r.ExecuteCommand("CLIENT", "UNPAUSE")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClientNoEvict excludes the current connection from the client eviction, if on.
+// Available since Redis 7.0.
+func (r *Redis) ClientNoEvict(on bool) error {
+	return r.clientSwitch("NO-EVICT", on)
+}
+
+// ClientNoTouch makes the commands of the current connection not change the LRU/LFU of the keys, if on.
+// Available since Redis 7.2.
+func (r *Redis) ClientNoTouch(on bool) error {
+	return r.clientSwitch("NO-TOUCH", on)
+}
+
+func (r *Redis) clientSwitch(subcommand string, on bool) error {
+	value := "OFF"
+	if on {
+		value = "ON"
+	}
+	rp, err := r.ExecuteCommand("CLIENT", subcommand, value)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ClientUnblock unblocks the client id, blocked in a command like BLPOP or XREAD,
+// as if its timeout was reached, or with an UNBLOCKED error if withError.
+// False if the client was not blocked.
+// Available since Redis 5.0.
+func (r *Redis) ClientUnblock(id int64, withError bool) (bool, error) {
+	args := packArgs("CLIENT", "UNBLOCK", id)
+	if withError {
+		args = append(args, "ERROR")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
 // ClientSetName assigns a name to the current connection.
 func (r *Redis) ClientSetName(name string) error {
 	rp, err := r.ExecuteCommand("CLIENT", "SETNAME", name)
@@ -164,6 +366,7 @@ func (r *Redis) FlushDB() error {
 // Info returns information and statistics about the server
 // in a format that is simple to parse by computers and easy to read by humans.
 // format document at http://redis.io/commands/info
+// InfoMap and ServerInfo return it parsed.
 func (r *Redis) Info(section string) (string, error) {
 	args := packArgs("INFO", section)
 	rp, err := r.ExecuteCommand(args...)
@@ -173,6 +376,21 @@ func (r *Redis) Info(section string) (string, error) {
 	return rp.StringValue()
 }
 
+// infoFields parses the field:value lines of an INFO reply, section headers are skipped.
+func infoFields(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 {
+			fields[line[:i]] = line[i+1:]
+		}
+	}
+	return fields
+}
+
 // LastSave return the UNIX TIME of the last DB save executed with success.
 // A client may check if a BGSAVE command succeeded reading the LASTSAVE value,
 // then issuing a BGSAVE command and checking at regular intervals every N seconds if LASTSAVE changed.
@@ -273,6 +491,8 @@ func (r *Redis) Shutdown(save, noSave bool) error {
 // So, if the old master stops working,
 // it is possible to turn the slave into a master and set the application to use this new master in read/write.
 // Later when the other Redis server is fixed, it can be reconfigured to work as a slave.
+//
+// Deprecated: use ReplicaOf and ReplicaOfNoOne.
 func (r *Redis) SlaveOf(host, port string) error {
 	rp, err := r.ExecuteCommand("SLAVEOF", host, port)
 	if err != nil {
@@ -281,6 +501,184 @@ func (r *Redis) SlaveOf(host, port string) error {
 	return rp.OKValue()
 }
 
+// ReplicaOf makes the server a replica of another server listening at host and port.
+// It replaces SLAVEOF since Redis 5.0.
+func (r *Redis) ReplicaOf(host, port string) error {
+	rp, err := r.ExecuteCommand("REPLICAOF", host, port)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// ReplicaOfNoOne stops replication, turning the server into a master,
+// the dataset replicated so far is kept.
+func (r *Redis) ReplicaOfNoOne() error {
+	return r.ReplicaOf("NO", "ONE")
+}
+
+// RoleInfo is the reply of ROLE, only the field matching Role is set.
+// Role is master, slave or sentinel.
+type RoleInfo struct {
+	Role     string
+	Master   *MasterRole
+	Replica  *ReplicaRole
+	Sentinel *SentinelRole
+}
+
+// MasterRole is the replication state of a master.
+type MasterRole struct {
+	ReplicationOffset int64
+	Replicas          []*ConnectedReplica
+}
+
+// ConnectedReplica is a replica connected to a master, with the offset it acknowledged.
+type ConnectedReplica struct {
+	Host              string
+	Port              int
+	ReplicationOffset int64
+}
+
+// ReplicaRole is the replication state of a replica.
+// State is connect, connecting, sync or connected.
+type ReplicaRole struct {
+	MasterHost        string
+	MasterPort        int
+	State             string
+	ReplicationOffset int64
+}
+
+// SentinelRole is the masters monitored by a sentinel.
+type SentinelRole struct {
+	MasterNames []string
+}
+
+// Role returns the role of the instance: master, slave or sentinel,
+// with the state of the replication or the monitored masters.
+func (r *Redis) Role() (*RoleInfo, error) {
+	rp, err := r.ExecuteCommand("ROLE")
+	if err != nil {
+		return nil, err
+	}
+	return roleValue(rp)
+}
+
+func roleValue(rp *Reply) (*RoleInfo, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if len(multi) == 0 {
+		return nil, errors.New("role protocol error")
+	}
+	role, err := multi[0].StringValue()
+	if err != nil {
+		return nil, err
+	}
+	info := &RoleInfo{Role: role}
+	switch role {
+	case "master":
+		if len(multi) != 3 {
+			return nil, errors.New("role protocol error")
+		}
+		offset, err := multi[1].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		info.Master = &MasterRole{ReplicationOffset: offset}
+		for _, subrp := range multi[2].Multi {
+			fields, err := subrp.ListValue()
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) != 3 {
+				return nil, errors.New("role protocol error")
+			}
+			port, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			offset, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			info.Master.Replicas = append(info.Master.Replicas, &ConnectedReplica{fields[0], port, offset})
+		}
+	case "slave":
+		if len(multi) != 5 {
+			return nil, errors.New("role protocol error")
+		}
+		host, err := multi[1].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		port, err := multi[2].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		state, err := multi[3].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		offset, err := multi[4].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		info.Replica = &ReplicaRole{host, int(port), state, offset}
+	case "sentinel":
+		if len(multi) != 2 {
+			return nil, errors.New("role protocol error")
+		}
+		names, err := multi[1].ListValue()
+		if err != nil {
+			return nil, err
+		}
+		info.Sentinel = &SentinelRole{names}
+	}
+	return info, nil
+}
+
+// Wait blocks until all the previous write commands sent on the connection
+// are acknowledged by at least numReplicas replicas, or timeout milliseconds passed.
+// A timeout of 0 blocks forever.
+// WAIT only makes sense for the writes sent on the same connection, so use it on a Session.
+// Integer reply: the number of replicas reached by the writes.
+func (r *Redis) Wait(numReplicas, timeout int) (int64, error) {
+	rp, err := r.ExecuteCommand("WAIT", numReplicas, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// WaitAOF blocks until all the previous write commands sent on the connection
+// are fsynced to the AOF of the local server and of at least numReplicas replicas,
+// or timeout milliseconds passed. Use it on a Session, like Wait.
+// It returns the number of local servers(0 or 1) and replicas which fsynced the writes.
+// Available since Redis 7.2.
+func (r *Redis) WaitAOF(numLocal, numReplicas, timeout int) (int64, int64, error) {
+	rp, err := r.ExecuteCommand("WAITAOF", numLocal, numReplicas, timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(multi) != 2 {
+		return 0, 0, errors.New("waitaof protocol error")
+	}
+	local, err := multi[0].IntegerValue()
+	if err != nil {
+		return 0, 0, err
+	}
+	replicas, err := multi[1].IntegerValue()
+	if err != nil {
+		return 0, 0, err
+	}
+	return local, replicas, nil
+}
+
 // SlowLog is used in order to read and reset the Redis slow queries log.
 type SlowLog struct {
 	ID           int64
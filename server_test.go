@@ -1,6 +1,8 @@
 package goredis
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -30,6 +32,87 @@ func TestClientList(t *testing.T) {
 	}
 }
 
+func TestClientCommands(t *testing.T) {
+	const line = "id=7 addr=10.0.0.5:51234 laddr=10.0.0.1:6379 fd=9 name=worker age=120 idle=3 flags=N db=2 sub=0 psub=0 multi=-1 qbuf=0 argv-mem=0 obl=0 oll=0 omem=0 tot-mem=22400 events=r cmd=client|list user=default resp=2"
+	var got []string
+	s := newFakeServer(t, func(args []string) interface{} {
+		got = args
+		if args[0] != "CLIENT" {
+			return errors.New("ERR unknown command")
+		}
+		switch args[1] {
+		case "LIST":
+			return line + "\nid=8 addr=10.0.0.6:40000 name= age=1 idle=0 flags=P db=0 cmd=subscribe\n"
+		case "INFO":
+			return line + "\n"
+		case "ID":
+			return 7
+		case "KILL":
+			return 2
+		case "UNBLOCK":
+			return 1
+		}
+		return fakeStatus("OK")
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+
+	clients, err := client.ClientList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients) != 2 {
+		t.Fatal(clients)
+	}
+	c := clients[0]
+	if c.ID != 7 || c.Addr != "10.0.0.5:51234" || c.LAddr != "10.0.0.1:6379" || c.Name != "worker" ||
+		c.Age != 2*time.Minute || c.Idle != 3*time.Second || c.Flags != "N" || c.DB != 2 ||
+		c.Cmd != "client|list" || c.User != "default" || c.TotMem != 22400 || c.Multi != -1 || c.Fields["resp"] != "2" {
+		t.Error(c)
+	}
+	if clients[1].Name != "" || clients[1].Flags != "P" || clients[1].LAddr != "" {
+		t.Error(clients[1])
+	}
+	if info, err := client.ClientInfo(); err != nil || info.ID != 7 {
+		t.Error(info, err)
+	}
+	if id, err := client.ClientID(); err != nil || id != 7 {
+		t.Error(id, err)
+	}
+	n, err := client.ClientKillWithArgs(&ClientKillArgs{Type: "pubsub", User: "default", SkipMe: ClientKillKillMe, MaxAge: 60})
+	if err != nil || n != 2 {
+		t.Error(n, err)
+	}
+	if strings.Join(got, " ") != "CLIENT KILL TYPE pubsub USER default SKIPME no MAXAGE 60" {
+		t.Error(got)
+	}
+	if _, err := client.ClientKillWithArgs(nil); err == nil {
+		t.Error("kill without args")
+	}
+	if _, err := client.ClientKillWithArgs(&ClientKillArgs{SkipMe: ClientKillSkipMe}); err == nil {
+		t.Error("kill without filter")
+	}
+	if err := client.ClientPauseWrite(100); err != nil || strings.Join(got, " ") != "CLIENT PAUSE 100 WRITE" {
+		t.Error(got, err)
+	}
+	if err := client.ClientUnpause(); err != nil {
+		t.Error(err)
+	}
+	if err := client.ClientNoEvict(true); err != nil || strings.Join(got, " ") != "CLIENT NO-EVICT ON" {
+		t.Error(got, err)
+	}
+	if err := client.ClientNoTouch(false); err != nil || strings.Join(got, " ") != "CLIENT NO-TOUCH OFF" {
+		t.Error(got, err)
+	}
+	if ok, err := client.ClientUnblock(8, true); err != nil || !ok || strings.Join(got, " ") != "CLIENT UNBLOCK 8 ERROR" {
+		t.Error(ok, got, err)
+	}
+}
+
 func TestClientGetName(t *testing.T) {
 	if _, err := r.ClientGetName(); err != nil {
 		t.Error(err)
@@ -138,6 +221,73 @@ func TestMonitor(t *testing.T) {
 	time.Sleep(100 * time.Microsecond)
 }
 
+func TestReplicaOfNoOne(t *testing.T) {
+	if err := r.ReplicaOfNoOne(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRole(t *testing.T) {
+	if role, err := r.Role(); err != nil {
+		t.Error(err)
+	} else if role.Role != "master" || role.Master == nil || role.Master.ReplicationOffset < 0 {
+		t.Fail()
+	}
+}
+
+func TestRoleValue(t *testing.T) {
+	replies := []interface{}{
+		[]interface{}{"master", 3129659, []interface{}{[]string{"127.0.0.1", "9001", "3129242"}}},
+		[]interface{}{"slave", "127.0.0.1", 9000, "connected", 3167038},
+		[]interface{}{"sentinel", []string{"resque-master", "html-fragments-master"}},
+	}
+	i := 0
+	s := newFakeServer(t, func(args []string) interface{} {
+		reply := replies[i%len(replies)]
+		i++
+		return reply
+	})
+	defer s.Close()
+	client, err := Dial(&DialConfig{Address: s.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	if role, err := client.Role(); err != nil {
+		t.Error(err)
+	} else if role.Master.ReplicationOffset != 3129659 || len(role.Master.Replicas) != 1 {
+		t.Fail()
+	} else if replica := role.Master.Replicas[0]; replica.Host != "127.0.0.1" || replica.Port != 9001 || replica.ReplicationOffset != 3129242 {
+		t.Fail()
+	}
+	if role, err := client.Role(); err != nil {
+		t.Error(err)
+	} else if role.Role != "slave" || role.Replica.MasterPort != 9000 || role.Replica.State != "connected" || role.Replica.ReplicationOffset != 3167038 {
+		t.Fail()
+	}
+	if role, err := client.Role(); err != nil {
+		t.Error(err)
+	} else if role.Role != "sentinel" || len(role.Sentinel.MasterNames) != 2 {
+		t.Fail()
+	}
+}
+
+func TestWait(t *testing.T) {
+	session, err := r.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if err := session.SimpleSet("key", "value"); err != nil {
+		t.Error(err)
+	}
+	if n, err := session.Wait(0, 100); err != nil {
+		t.Error(err)
+	} else if n < 0 {
+		t.Fail()
+	}
+}
+
 func TestSave(t *testing.T) {
 	if err := r.Save(); err != nil {
 		t.Error(err)
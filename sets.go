@@ -1,9 +1,5 @@
 package goredis
 
-import (
-	"strconv"
-)
-
 // SAdd add the specified members to the set stored at key.
 // Specified members that are already a member of this set are ignored.
 // If key does not exist, a new set is created before adding the specified members.
@@ -66,6 +62,21 @@ func (r *Redis) SInter(keys ...string) ([]string, error) {
 	return rp.ListValue()
 }
 
+// SInterCard returns the number of members in the intersection of the sets of keys,
+// stopping at limit if limit is not 0.
+// Available since Redis 7.0.
+func (r *Redis) SInterCard(limit int, keys ...string) (int64, error) {
+	args := packArgs("SINTERCARD", len(keys), keys)
+	if limit > 0 {
+		args = append(args, "LIMIT", limit)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // SInterStore is equal to SINTER, but instead of returning the resulting set,
 // it is stored in destination.
 // If destination already exists, it is overwritten.
@@ -97,6 +108,17 @@ func (r *Redis) SMembers(key string) ([]string, error) {
 	return rp.ListValue()
 }
 
+// SMIsMember returns whether each of members is a member of the set stored at key.
+// Available since Redis 6.2.
+func (r *Redis) SMIsMember(key string, members ...string) ([]bool, error) {
+	args := packArgs("SMISMEMBER", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BoolArrayValue()
+}
+
 // SMove moves member from the set at source to the set at destination.
 // This operation is atomic.
 // In every given moment the element will appear to be a member of source or destination for other clients.
@@ -118,6 +140,17 @@ func (r *Redis) SPop(key string) ([]byte, error) {
 	return rp.BytesValue()
 }
 
+// SPopCount removes and returns up to count random members from the set value stored at key.
+// Multi-bulk reply: the removed members, empty when key does not exist.
+// Available since Redis 3.2.
+func (r *Redis) SPopCount(key string, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("SPOP", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
 // SRandMember returns a random element from the set value stored at key.
 // Bulk reply: the command returns a Bulk Reply with the randomly selected element,
 // or nil when key does not exist.
@@ -181,6 +214,8 @@ func (r *Redis) SUnionStore(destination string, keys ...string) (int64, error) {
 }
 
 // SScan key cursor [MATCH pattern] [COUNT count]
+// It returns the cursor of the next call, 0 once the iteration is complete,
+// and the members of this batch, which may be empty while the iteration goes on.
 func (r *Redis) SScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
 	args := packArgs("SSCAN", key, cursor)
 	if pattern != "" {
@@ -193,14 +228,5 @@ func (r *Redis) SScan(key string, cursor uint64, pattern string, count int) (uin
 	if err != nil {
 		return 0, nil, err
 	}
-	first, err := rp.Multi[0].StringValue()
-	if err != nil {
-		return 0, nil, err
-	}
-	next, err := strconv.ParseUint(first, 10, 64)
-	if err != nil {
-		return 0, nil, err
-	}
-	list, err := rp.Multi[1].ListValue()
-	return next, list, err
+	return scanValue(rp)
 }
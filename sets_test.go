@@ -62,6 +62,20 @@ func TestSInter(t *testing.T) {
 	}
 }
 
+func TestSInterCard(t *testing.T) {
+	r.Del("key1", "key2")
+	r.SAdd("key1", "a", "b", "c")
+	r.SAdd("key2", "b", "c", "d")
+	if n, err := r.SInterCard(0, "key1", "key2"); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Fail()
+	}
+	if n, _ := r.SInterCard(1, "key1", "key2"); n != 1 {
+		t.Fail()
+	}
+}
+
 func TestSInterStore(t *testing.T) {
 	r.Del("key1", "key2", "key3")
 	r.SAdd("key1", "a", "b", "c", "d")
@@ -87,6 +101,16 @@ func TestSIsMember(t *testing.T) {
 	}
 }
 
+func TestSMIsMember(t *testing.T) {
+	r.Del("key")
+	r.SAdd("key", "one", "two")
+	if result, err := r.SMIsMember("key", "one", "three", "two"); err != nil {
+		t.Error(err)
+	} else if len(result) != 3 || !result[0] || result[1] || !result[2] {
+		t.Fail()
+	}
+}
+
 func TestSMembers(t *testing.T) {
 	r.Del("key")
 	r.SAdd("key", "value")
@@ -124,6 +148,24 @@ func TestSPop(t *testing.T) {
 	}
 }
 
+func TestSPopCount(t *testing.T) {
+	r.Del("key")
+	r.SAdd("key", "one", "two", "three")
+	if items, err := r.SPopCount("key", 2); err != nil {
+		t.Error(err)
+	} else if len(items) != 2 {
+		t.Fail()
+	}
+	if items, _ := r.SPopCount("key", 5); len(items) != 1 {
+		t.Fail()
+	}
+	if items, err := r.SPopCount("key", 5); err != nil {
+		t.Error(err)
+	} else if len(items) != 0 {
+		t.Fail()
+	}
+}
+
 func TestSRandMember(t *testing.T) {
 	r.Del("key")
 	r.SAdd("key", "one", "two", "three")
@@ -186,4 +228,8 @@ func TestSScan(t *testing.T) {
 	} else if len(list) == 0 {
 		t.Fail()
 	}
+	r.Set("string", "value", 0, 0, false, false)
+	if _, _, err := r.SScan("string", 0, "", 0); err == nil {
+		t.Fail()
+	}
 }
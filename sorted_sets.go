@@ -5,6 +5,29 @@ import (
 	"strconv"
 )
 
+// Z is a member of a sorted set with its score.
+type Z struct {
+	Member string
+	Score  float64
+}
+
+func zValues(list []string, err error) ([]Z, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(list)%2 != 0 {
+		return nil, errors.New("member score list protocol error")
+	}
+	zs := make([]Z, len(list)/2)
+	for i := range zs {
+		zs[i].Member = list[2*i]
+		if zs[i].Score, err = strconv.ParseFloat(list[2*i+1], 64); err != nil {
+			return nil, err
+		}
+	}
+	return zs, nil
+}
+
 // ZAdd adds all the specified members with the specified scores to the sorted set stored at key.
 // If a specified member is already a member of the sorted set,
 // the score is updated and the element reinserted at the right position to ensure the correct ordering.
@@ -27,6 +50,75 @@ func (r *Redis) ZAdd(key string, pairs map[string]float64) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// ZAddArgs is the options of ZADD.
+// NX only adds new members, XX only updates existing ones.
+// GT and LT only update existing members when the new score is greater or less than the current one.
+// CH counts the updated members in the reply too.
+// GT and LT need Redis 6.2, NX, XX and CH need Redis 3.0.2.
+type ZAddArgs struct {
+	NX bool
+	XX bool
+	GT bool
+	LT bool
+	CH bool
+}
+
+func (a *ZAddArgs) args() []interface{} {
+	var args []interface{}
+	if a == nil {
+		return args
+	}
+	if a.NX {
+		args = append(args, "NX")
+	}
+	if a.XX {
+		args = append(args, "XX")
+	}
+	if a.GT {
+		args = append(args, "GT")
+	}
+	if a.LT {
+		args = append(args, "LT")
+	}
+	if a.CH {
+		args = append(args, "CH")
+	}
+	return args
+}
+
+// ZAddWithArgs is ZAdd with options, members are added in order. args may be nil.
+// Integer reply: the number of members added, or changed with CH.
+func (r *Redis) ZAddWithArgs(key string, args *ZAddArgs, members ...Z) (int64, error) {
+	cmds := packArgs("ZADD", key, args.args())
+	for _, z := range members {
+		cmds = append(cmds, z.Score, z.Member)
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZAddIncr increments the score of member.Member by member.Score, like ZIncrBy but with options (ZADD INCR).
+// It returns the new score, or nil when the operation was aborted by the options.
+func (r *Redis) ZAddIncr(key string, args *ZAddArgs, member Z) (*float64, error) {
+	cmds := packArgs("ZADD", key, args.args(), "INCR", member.Score, member.Member)
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return nil, err
+	}
+	b, err := rp.BytesValue()
+	if err != nil || b == nil {
+		return nil, err
+	}
+	score, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
 // ZCard returns the sorted set cardinality (number of elements) of the sorted set stored at key.
 // Integer reply: the cardinality (number of elements) of the sorted set, or 0 if key does not exist.
 func (r *Redis) ZCard(key string) (int64, error) {
@@ -71,17 +163,8 @@ func (r *Redis) ZIncrBy(key string, increment float64, member string) (float64,
 }
 
 // ZInterStore destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
-func (r *Redis) ZInterStore(destination string, keys []string, weights []int, aggregate string) (int64, error) {
-	args := packArgs("ZINTERSTORE", destination, len(keys), keys)
-	if weights != nil && len(weights) > 0 {
-		args = append(args, "WEIGHTS")
-		for _, w := range weights {
-			args = append(args, w)
-		}
-	}
-	if aggregate != "" {
-		args = append(args, "AGGREGATE", aggregate)
-	}
+func (r *Redis) ZInterStore(destination string, keys []string, weights []float64, aggregate string) (int64, error) {
+	args := packArgs("ZINTERSTORE", destination, len(keys), keys, zAggregateArgs(weights, aggregate))
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return 0, err
@@ -118,6 +201,11 @@ func (r *Redis) ZRange(key string, start, stop int, withscores bool) ([]string,
 	return rp.ListValue()
 }
 
+// ZRangeWithScores is ZRange with WITHSCORES, returning the members with their scores.
+func (r *Redis) ZRangeWithScores(key string, start, stop int) ([]Z, error) {
+	return zValues(r.ZRange(key, start, stop, true))
+}
+
 // ZRangeByLex returns all the elements in the sorted set at key with a value between min and max
 // in order to force lexicographical ordering.
 func (r *Redis) ZRangeByLex(key, min, max string, limit bool, offset, count int) ([]string, error) {
@@ -148,6 +236,11 @@ func (r *Redis) ZRangeByScore(key, min, max string, withscores, limit bool, offs
 	return rp.ListValue()
 }
 
+// ZRangeByScoreWithScores is ZRangeByScore with WITHSCORES, returning the members with their scores.
+func (r *Redis) ZRangeByScoreWithScores(key, min, max string, limit bool, offset, count int) ([]Z, error) {
+	return zValues(r.ZRangeByScore(key, min, max, true, limit, offset, count))
+}
+
 // ZRank returns the rank of member in the sorted set stored at key,
 // with the scores ordered from low to high.
 // The rank (or index) is 0-based, which means that the member with the lowest score has rank 0.
@@ -234,6 +327,11 @@ func (r *Redis) ZRevRange(key string, start, stop int, withscores bool) ([]strin
 	return rp.ListValue()
 }
 
+// ZRevRangeWithScores is ZRevRange with WITHSCORES, returning the members with their scores.
+func (r *Redis) ZRevRangeWithScores(key string, start, stop int) ([]Z, error) {
+	return zValues(r.ZRevRange(key, start, stop, true))
+}
+
 // ZRevRangeByScore key max min [WITHSCORES] [LIMIT offset count]
 func (r *Redis) ZRevRangeByScore(key, max, min string, withscores, limit bool, offset, count int) ([]string, error) {
 	args := packArgs("ZREVRANGEBYSCORE", key, max, min)
@@ -250,6 +348,11 @@ func (r *Redis) ZRevRangeByScore(key, max, min string, withscores, limit bool, o
 	return rp.ListValue()
 }
 
+// ZRevRangeByScoreWithScores is ZRevRangeByScore with WITHSCORES, returning the members with their scores.
+func (r *Redis) ZRevRangeByScoreWithScores(key, max, min string, limit bool, offset, count int) ([]Z, error) {
+	return zValues(r.ZRevRangeByScore(key, max, min, true, limit, offset, count))
+}
+
 // ZRevRank returns the rank of member in the sorted set stored at key,
 // with the scores ordered from high to low. The rank (or index) is 0-based,
 // which means that the member with the highest score has rank 0.
@@ -282,17 +385,8 @@ func (r *Redis) ZScore(key, member string) ([]byte, error) {
 }
 
 // ZUnionStore destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
-func (r *Redis) ZUnionStore(destination string, keys []string, weights []int, aggregate string) (int64, error) {
-	args := packArgs("ZUNIONSTORE", destination, len(keys), keys)
-	if weights != nil && len(weights) > 0 {
-		args = append(args, "WEIGHTS")
-		for _, w := range weights {
-			args = append(args, w)
-		}
-	}
-	if aggregate != "" {
-		args = append(args, "AGGREGATE", aggregate)
-	}
+func (r *Redis) ZUnionStore(destination string, keys []string, weights []float64, aggregate string) (int64, error) {
+	args := packArgs("ZUNIONSTORE", destination, len(keys), keys, zAggregateArgs(weights, aggregate))
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return 0, err
@@ -313,14 +407,357 @@ func (r *Redis) ZScan(key string, cursor uint64, pattern string, count int) (uin
 	if err != nil {
 		return 0, nil, err
 	}
-	first, err := rp.Multi[0].StringValue()
+	return scanValue(rp)
+}
+
+// ZScanWithScores is ZScan returning the members with their scores.
+func (r *Redis) ZScanWithScores(key string, cursor uint64, pattern string, count int) (uint64, []Z, error) {
+	next, list, err := r.ZScan(key, cursor, pattern, count)
 	if err != nil {
 		return 0, nil, err
 	}
-	next, err := strconv.ParseUint(first, 10, 64)
+	zs, err := zValues(list, nil)
+	return next, zs, err
+}
+
+func zAggregateArgs(weights []float64, aggregate string) []interface{} {
+	var args []interface{}
+	if len(weights) > 0 {
+		args = append(args, "WEIGHTS")
+		for _, w := range weights {
+			args = append(args, w)
+		}
+	}
+	if aggregate != "" {
+		args = append(args, "AGGREGATE", aggregate)
+	}
+	return args
+}
+
+// Sides of a sorted set for ZMPop and BZMPop.
+const (
+	ZMin = "MIN"
+	ZMax = "MAX"
+)
+
+// ZPopMin removes and returns up to count members with the lowest scores in the sorted set stored at key,
+// one if count is 0.
+// Available since Redis 5.0.
+func (r *Redis) ZPopMin(key string, count int) ([]Z, error) {
+	return r.zPop("ZPOPMIN", key, count)
+}
+
+// ZPopMax removes and returns up to count members with the highest scores in the sorted set stored at key,
+// one if count is 0.
+// Available since Redis 5.0.
+func (r *Redis) ZPopMax(key string, count int) ([]Z, error) {
+	return r.zPop("ZPOPMAX", key, count)
+}
+
+func (r *Redis) zPop(cmd, key string, count int) ([]Z, error) {
+	args := packArgs(cmd, key)
+	if count > 0 {
+		args = append(args, count)
+	}
+	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// BZPopMin is the blocking variant of ZPopMin, popping one member from the first non-empty sorted set of keys.
+// It returns the key of the sorted set and the popped member, an empty key when the timeout is reached.
+// A timeout of zero can be used to block indefinitely.
+// Available since Redis 5.0.
+func (r *Redis) BZPopMin(keys []string, timeout int) (string, Z, error) {
+	return r.bzPop("BZPOPMIN", keys, timeout)
+}
+
+// BZPopMax is the blocking variant of ZPopMax, see BZPopMin.
+// Available since Redis 5.0.
+func (r *Redis) BZPopMax(keys []string, timeout int) (string, Z, error) {
+	return r.bzPop("BZPOPMAX", keys, timeout)
+}
+
+func (r *Redis) bzPop(cmd string, keys []string, timeout int) (string, Z, error) {
+	args := packArgs(cmd, keys, timeout)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return "", Z{}, err
+	}
+	list, err := rp.ListValue()
+	if err != nil || list == nil {
+		return "", Z{}, err
+	}
+	if len(list) != 3 {
+		return "", Z{}, errors.New("bzpop protocol error")
+	}
+	zs, err := zValues(list[1:], nil)
+	if err != nil {
+		return "", Z{}, err
+	}
+	return list[0], zs[0], nil
+}
+
+// ZMPop pops up to count members, one if count is 0, with the lowest (where ZMin) or highest (where ZMax) scores
+// from the first non-empty sorted set of keys.
+// It returns the key of the sorted set and the popped members, an empty key when every sorted set is empty.
+// Available since Redis 7.0.
+func (r *Redis) ZMPop(where string, count int, keys ...string) (string, []Z, error) {
+	args := packArgs("ZMPOP", len(keys), keys, where)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	return zmpopValue(r.ExecuteCommand(args...))
+}
+
+// BZMPop is the blocking variant of ZMPop.
+// An empty key is returned when the timeout is reached.
+// Available since Redis 7.0.
+func (r *Redis) BZMPop(timeout int, where string, count int, keys ...string) (string, []Z, error) {
+	args := packArgs("BZMPOP", timeout, len(keys), keys, where)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	return zmpopValue(r.ExecuteCommand(args...))
+}
+
+func zmpopValue(rp *Reply, err error) (string, []Z, error) {
+	if err != nil {
+		return "", nil, err
 	}
-	list, err := rp.Multi[1].ListValue()
-	return next, list, err
+	multi, err := rp.MultiValue()
+	if err != nil || multi == nil {
+		return "", nil, err
+	}
+	if len(multi) != 2 {
+		return "", nil, errors.New("zmpop protocol error")
+	}
+	key, err := multi[0].StringValue()
+	if err != nil {
+		return "", nil, err
+	}
+	// The members come as [member, score] pairs.
+	var list []string
+	for _, subrp := range multi[1].Multi {
+		pair, err := subrp.ListValue()
+		if err != nil {
+			return "", nil, err
+		}
+		list = append(list, pair...)
+	}
+	zs, err := zValues(list, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, zs, nil
+}
+
+// ZRandMember returns random members of the sorted set stored at key, one if count is 0.
+// With a positive count the members are distinct, with a negative count the same member may be returned multiple times.
+// Available since Redis 6.2.
+func (r *Redis) ZRandMember(key string, count int) ([]string, error) {
+	if count == 0 {
+		rp, err := r.ExecuteCommand("ZRANDMEMBER", key)
+		if err != nil {
+			return nil, err
+		}
+		b, err := rp.BytesValue()
+		if err != nil || b == nil {
+			return nil, err
+		}
+		return []string{string(b)}, nil
+	}
+	rp, err := r.ExecuteCommand("ZRANDMEMBER", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZRandMemberWithScores is ZRandMember with WITHSCORES, count can not be 0.
+func (r *Redis) ZRandMemberWithScores(key string, count int) ([]Z, error) {
+	rp, err := r.ExecuteCommand("ZRANDMEMBER", key, count, "WITHSCORES")
+	if err != nil {
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// ZMScore returns the scores of members in the sorted set stored at key,
+// nil for the members which do not exist.
+// Available since Redis 6.2.
+func (r *Redis) ZMScore(key string, members ...string) ([]*float64, error) {
+	args := packArgs("ZMSCORE", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := rp.BytesArrayValue()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*float64, len(scores))
+	for i, b := range scores {
+		if b == nil {
+			continue
+		}
+		score, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &score
+	}
+	return result, nil
+}
+
+// ZUnion is ZUnionStore returning the resulting members instead of storing them.
+// Available since Redis 6.2.
+func (r *Redis) ZUnion(keys []string, weights []float64, aggregate string) ([]string, error) {
+	args := packArgs("ZUNION", len(keys), keys, zAggregateArgs(weights, aggregate))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZUnionWithScores is ZUnion with WITHSCORES.
+func (r *Redis) ZUnionWithScores(keys []string, weights []float64, aggregate string) ([]Z, error) {
+	args := packArgs("ZUNION", len(keys), keys, zAggregateArgs(weights, aggregate), "WITHSCORES")
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// ZInter is ZInterStore returning the resulting members instead of storing them.
+// Available since Redis 6.2.
+func (r *Redis) ZInter(keys []string, weights []float64, aggregate string) ([]string, error) {
+	args := packArgs("ZINTER", len(keys), keys, zAggregateArgs(weights, aggregate))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZInterWithScores is ZInter with WITHSCORES.
+func (r *Redis) ZInterWithScores(keys []string, weights []float64, aggregate string) ([]Z, error) {
+	args := packArgs("ZINTER", len(keys), keys, zAggregateArgs(weights, aggregate), "WITHSCORES")
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// ZInterCard returns the number of members in the intersection of the sorted sets of keys,
+// stopping at limit if limit is not 0.
+// Available since Redis 7.0.
+func (r *Redis) ZInterCard(limit int, keys ...string) (int64, error) {
+	args := packArgs("ZINTERCARD", len(keys), keys)
+	if limit > 0 {
+		args = append(args, "LIMIT", limit)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZDiff returns the members of the first sorted set of keys which are not in the following ones.
+// Available since Redis 6.2.
+func (r *Redis) ZDiff(keys ...string) ([]string, error) {
+	args := packArgs("ZDIFF", len(keys), keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZDiffWithScores is ZDiff with WITHSCORES.
+func (r *Redis) ZDiffWithScores(keys ...string) ([]Z, error) {
+	args := packArgs("ZDIFF", len(keys), keys, "WITHSCORES")
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// ZDiffStore is ZDiff storing the result into destination.
+// Integer reply: the number of members in destination.
+// Available since Redis 6.2.
+func (r *Redis) ZDiffStore(destination string, keys ...string) (int64, error) {
+	args := packArgs("ZDIFFSTORE", destination, len(keys), keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZRangeArgs is the arguments of the unified ZRANGE form of Redis 6.2.
+// Start and Stop are indexes, or scores with ByScore, or lexicographical ranges like [a and (b with ByLex.
+// Rev reverses the order, so Start must be the highest bound for ByScore and ByLex.
+// Limit, Offset and Count are only valid with ByScore or ByLex.
+type ZRangeArgs struct {
+	Start   string
+	Stop    string
+	ByScore bool
+	ByLex   bool
+	Rev     bool
+	Limit   bool
+	Offset  int
+	Count   int
+}
+
+func (a *ZRangeArgs) args() []interface{} {
+	args := []interface{}{a.Start, a.Stop}
+	if a.ByScore {
+		args = append(args, "BYSCORE")
+	} else if a.ByLex {
+		args = append(args, "BYLEX")
+	}
+	if a.Rev {
+		args = append(args, "REV")
+	}
+	if a.Limit {
+		args = append(args, "LIMIT", a.Offset, a.Count)
+	}
+	return args
+}
+
+// ZRangeWithArgs returns the members of the sorted set stored at key in the range of args.
+func (r *Redis) ZRangeWithArgs(key string, args *ZRangeArgs) ([]string, error) {
+	rp, err := r.ExecuteCommand(packArgs("ZRANGE", key, args.args())...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZRangeWithArgsWithScores is ZRangeWithArgs with WITHSCORES.
+func (r *Redis) ZRangeWithArgsWithScores(key string, args *ZRangeArgs) ([]Z, error) {
+	rp, err := r.ExecuteCommand(packArgs("ZRANGE", key, args.args(), "WITHSCORES")...)
+	if err != nil {
+		return nil, err
+	}
+	return zValues(rp.ListValue())
+}
+
+// ZRangeStore is ZRangeWithArgs storing the members into destination.
+// Integer reply: the number of members in destination.
+// Available since Redis 6.2.
+func (r *Redis) ZRangeStore(destination, source string, args *ZRangeArgs) (int64, error) {
+	rp, err := r.ExecuteCommand(packArgs("ZRANGESTORE", destination, source, args.args())...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
 }
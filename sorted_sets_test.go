@@ -21,6 +21,41 @@ func TestZAdd(t *testing.T) {
 	}
 }
 
+func TestZAddWithArgs(t *testing.T) {
+	r.Del("key")
+	if n, err := r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2}); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Fail()
+	}
+	if n, _ := r.ZAddWithArgs("key", &ZAddArgs{NX: true}, Z{"one", 5}, Z{"three", 3}); n != 1 {
+		t.Fail()
+	}
+	if n, _ := r.ZAddWithArgs("key", &ZAddArgs{XX: true, CH: true}, Z{"one", 10}, Z{"four", 4}); n != 1 {
+		t.Fail()
+	}
+	if n, _ := r.ZAddWithArgs("key", &ZAddArgs{GT: true, CH: true}, Z{"one", 1}, Z{"two", 20}); n != 1 {
+		t.Fail()
+	}
+	if score, _ := r.ZScore("key", "one"); string(score) != "10" {
+		t.Fail()
+	}
+}
+
+func TestZAddIncr(t *testing.T) {
+	r.Del("key")
+	if score, err := r.ZAddIncr("key", nil, Z{"one", 1.5}); err != nil {
+		t.Error(err)
+	} else if score == nil || *score != 1.5 {
+		t.Fail()
+	}
+	if score, err := r.ZAddIncr("key", &ZAddArgs{NX: true}, Z{"one", 1}); err != nil {
+		t.Error(err)
+	} else if score != nil {
+		t.Fail()
+	}
+}
+
 func TestZCard(t *testing.T) {
 	r.Del("key")
 	pairs := map[string]float64{
@@ -95,6 +130,31 @@ func TestZRange(t *testing.T) {
 	}
 }
 
+func TestZRangeWithScores(t *testing.T) {
+	r.Del("key")
+	r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3.5})
+	if zs, err := r.ZRangeWithScores("key", 0, -1); err != nil {
+		t.Error(err)
+	} else if len(zs) != 3 || zs[0] != (Z{"one", 1}) || zs[2] != (Z{"three", 3.5}) {
+		t.Fail()
+	}
+	if zs, err := r.ZRevRangeWithScores("key", 0, 0); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"three", 3.5}) {
+		t.Fail()
+	}
+	if zs, err := r.ZRangeByScoreWithScores("key", "(1", "+inf", true, 1, 1); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"three", 3.5}) {
+		t.Fail()
+	}
+	if zs, err := r.ZRevRangeByScoreWithScores("key", "2", "-inf", false, 0, 0); err != nil {
+		t.Error(err)
+	} else if len(zs) != 2 || zs[0] != (Z{"two", 2}) {
+		t.Fail()
+	}
+}
+
 func TestZRank(t *testing.T) {
 	r.Del("key")
 	pairs := map[string]float64{
@@ -232,6 +292,16 @@ func TestZScan(t *testing.T) {
 	}
 }
 
+func TestZScanWithScores(t *testing.T) {
+	r.Del("key")
+	r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2})
+	if _, zs, err := r.ZScanWithScores("key", 0, "o*", 0); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"one", 1}) {
+		t.Fail()
+	}
+}
+
 func TestZInterStore(t *testing.T) {
 	r.Del("zset1", "zset2")
 	r.ZAdd("zset1", map[string]float64{
@@ -243,7 +313,7 @@ func TestZInterStore(t *testing.T) {
 		"two":   2,
 		"three": 3,
 	})
-	if n, err := r.ZInterStore("out", []string{"zset1", "zset2"}, []int{2, 3}, ""); err != nil {
+	if n, err := r.ZInterStore("out", []string{"zset1", "zset2"}, []float64{2, 3.5}, ""); err != nil {
 		t.Error(err)
 	} else if n != 2 {
 		t.Fail()
@@ -261,7 +331,7 @@ func TestZUnionStore(t *testing.T) {
 		"two":   2,
 		"three": 3,
 	})
-	if n, err := r.ZUnionStore("out", []string{"zset1", "zset2"}, []int{2, 3}, ""); err != nil {
+	if n, err := r.ZUnionStore("out", []string{"zset1", "zset2"}, []float64{2, 3.5}, ""); err != nil {
 		t.Error(err)
 	} else if n != 3 {
 		t.Fail()
@@ -295,3 +365,172 @@ func TestZRevRangeByScore(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestZPopMin(t *testing.T) {
+	r.Del("key")
+	r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	if zs, err := r.ZPopMin("key", 0); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"one", 1}) {
+		t.Fail()
+	}
+	if zs, err := r.ZPopMax("key", 5); err != nil {
+		t.Error(err)
+	} else if len(zs) != 2 || zs[0] != (Z{"three", 3}) || zs[1] != (Z{"two", 2}) {
+		t.Fail()
+	}
+}
+
+func TestBZPopMin(t *testing.T) {
+	r.Del("key", "key1")
+	if key, _, err := r.BZPopMin([]string{"key", "key1"}, 1); err != nil {
+		t.Error(err)
+	} else if key != "" {
+		t.Fail()
+	}
+	r.ZAddWithArgs("key1", nil, Z{"one", 1}, Z{"two", 2})
+	if key, z, err := r.BZPopMin([]string{"key", "key1"}, 1); err != nil {
+		t.Error(err)
+	} else if key != "key1" || z != (Z{"one", 1}) {
+		t.Fail()
+	}
+	if key, z, err := r.BZPopMax([]string{"key1"}, 1); err != nil {
+		t.Error(err)
+	} else if key != "key1" || z != (Z{"two", 2}) {
+		t.Fail()
+	}
+}
+
+func TestZMPop(t *testing.T) {
+	r.Del("key", "key1")
+	if key, zs, err := r.ZMPop(ZMin, 0, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "" || zs != nil {
+		t.Fail()
+	}
+	r.ZAddWithArgs("key1", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	if key, zs, err := r.ZMPop(ZMax, 2, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "key1" || len(zs) != 2 || zs[0] != (Z{"three", 3}) {
+		t.Fail()
+	}
+	if key, zs, err := r.BZMPop(1, ZMin, 0, "key", "key1"); err != nil {
+		t.Error(err)
+	} else if key != "key1" || len(zs) != 1 || zs[0] != (Z{"one", 1}) {
+		t.Fail()
+	}
+}
+
+func TestZRandMember(t *testing.T) {
+	r.Del("key")
+	if members, err := r.ZRandMember("key", 0); err != nil {
+		t.Error(err)
+	} else if members != nil {
+		t.Fail()
+	}
+	r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2})
+	if members, err := r.ZRandMember("key", 0); err != nil {
+		t.Error(err)
+	} else if len(members) != 1 {
+		t.Fail()
+	}
+	if members, err := r.ZRandMember("key", -5); err != nil {
+		t.Error(err)
+	} else if len(members) != 5 {
+		t.Fail()
+	}
+	if zs, err := r.ZRandMemberWithScores("key", 5); err != nil {
+		t.Error(err)
+	} else if len(zs) != 2 || (zs[0].Member == "one" && zs[0].Score != 1) {
+		t.Fail()
+	}
+}
+
+func TestZMScore(t *testing.T) {
+	r.Del("key")
+	r.ZAddWithArgs("key", nil, Z{"one", 1.5})
+	if scores, err := r.ZMScore("key", "one", "nofield"); err != nil {
+		t.Error(err)
+	} else if len(scores) != 2 || scores[0] == nil || *scores[0] != 1.5 || scores[1] != nil {
+		t.Fail()
+	}
+}
+
+func TestZUnion(t *testing.T) {
+	r.Del("zset1", "zset2")
+	r.ZAddWithArgs("zset1", nil, Z{"one", 1}, Z{"two", 2})
+	r.ZAddWithArgs("zset2", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	if members, err := r.ZUnion([]string{"zset1", "zset2"}, nil, ""); err != nil {
+		t.Error(err)
+	} else if len(members) != 3 {
+		t.Fail()
+	}
+	if zs, err := r.ZUnionWithScores([]string{"zset1", "zset2"}, []float64{1, 0.5}, "MAX"); err != nil {
+		t.Error(err)
+	} else if len(zs) != 3 || zs[0] != (Z{"one", 1}) || zs[2] != (Z{"two", 2}) {
+		t.Fail()
+	}
+}
+
+func TestZInter(t *testing.T) {
+	r.Del("zset1", "zset2")
+	r.ZAddWithArgs("zset1", nil, Z{"one", 1}, Z{"two", 2})
+	r.ZAddWithArgs("zset2", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	if members, err := r.ZInter([]string{"zset1", "zset2"}, nil, ""); err != nil {
+		t.Error(err)
+	} else if len(members) != 2 {
+		t.Fail()
+	}
+	if zs, err := r.ZInterWithScores([]string{"zset1", "zset2"}, []float64{2, 3}, ""); err != nil {
+		t.Error(err)
+	} else if len(zs) != 2 || zs[0] != (Z{"one", 5}) || zs[1] != (Z{"two", 10}) {
+		t.Fail()
+	}
+	if n, err := r.ZInterCard(1, "zset1", "zset2"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
+func TestZDiff(t *testing.T) {
+	r.Del("zset1", "zset2", "out")
+	r.ZAddWithArgs("zset1", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	r.ZAddWithArgs("zset2", nil, Z{"one", 1}, Z{"two", 2})
+	if members, err := r.ZDiff("zset1", "zset2"); err != nil {
+		t.Error(err)
+	} else if len(members) != 1 || members[0] != "three" {
+		t.Fail()
+	}
+	if zs, err := r.ZDiffWithScores("zset1", "zset2"); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"three", 3}) {
+		t.Fail()
+	}
+	if n, err := r.ZDiffStore("out", "zset1", "zset2"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
+func TestZRangeWithArgs(t *testing.T) {
+	r.Del("key", "out")
+	r.ZAddWithArgs("key", nil, Z{"one", 1}, Z{"two", 2}, Z{"three", 3})
+	if members, err := r.ZRangeWithArgs("key", &ZRangeArgs{Start: "0", Stop: "1", Rev: true}); err != nil {
+		t.Error(err)
+	} else if len(members) != 2 || members[0] != "three" {
+		t.Fail()
+	}
+	args := &ZRangeArgs{Start: "+inf", Stop: "(1", ByScore: true, Rev: true, Limit: true, Offset: 1, Count: 5}
+	if zs, err := r.ZRangeWithArgsWithScores("key", args); err != nil {
+		t.Error(err)
+	} else if len(zs) != 1 || zs[0] != (Z{"two", 2}) {
+		t.Fail()
+	}
+	if n, err := r.ZRangeStore("out", "key", &ZRangeArgs{Start: "1", Stop: "3", ByScore: true}); err != nil {
+		t.Error(err)
+	} else if n != 3 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,683 @@
+package goredis
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// StreamEntry is an entry of a stream, the ID and the field value pairs.
+type StreamEntry struct {
+	ID     string
+	Fields map[string]string
+}
+
+// Stream is the entries read from the stream stored at Key.
+type Stream struct {
+	Key     string
+	Entries []*StreamEntry
+}
+
+// XTrimArgs is the trimming strategy of XTRIM and XADD.
+// The stream is trimmed by MINID when MinID is set, otherwise by MAXLEN.
+// Approx uses the ~ modifier, which lets redis trim whole macro nodes only,
+// and Limit caps the number of entries evicted when Approx is set.
+type XTrimArgs struct {
+	MaxLen int64
+	MinID  string
+	Approx bool
+	Limit  int64
+}
+
+func (a *XTrimArgs) args() []interface{} {
+	var args []interface{}
+	if a.MinID != "" {
+		args = append(args, "MINID")
+	} else {
+		args = append(args, "MAXLEN")
+	}
+	if a.Approx {
+		args = append(args, "~")
+	}
+	if a.MinID != "" {
+		args = append(args, a.MinID)
+	} else {
+		args = append(args, a.MaxLen)
+	}
+	if a.Approx && a.Limit > 0 {
+		args = append(args, "LIMIT", a.Limit)
+	}
+	return args
+}
+
+// XAddArgs is the optional arguments of XADD.
+// ID is the entry ID, empty lets the server generate one.
+// NoMkStream does not create the stream when it does not exist.
+// Trim trims the stream after adding the entry.
+type XAddArgs struct {
+	ID         string
+	NoMkStream bool
+	Trim       *XTrimArgs
+}
+
+// XAdd appends an entry with fields to the stream stored at key, args may be nil.
+// Bulk reply: the ID of the added entry,
+// or an empty string when NoMkStream is set and the stream does not exist.
+func (r *Redis) XAdd(key string, fields map[string]string, args *XAddArgs) (string, error) {
+	cmds := packArgs("XADD", key)
+	id := "*"
+	if args != nil {
+		if args.NoMkStream {
+			cmds = append(cmds, "NOMKSTREAM")
+		}
+		if args.Trim != nil {
+			cmds = append(cmds, args.Trim.args()...)
+		}
+		if args.ID != "" {
+			id = args.ID
+		}
+	}
+	cmds = append(cmds, id)
+	cmds = append(cmds, packArgs(fields)...)
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// XLen returns the number of entries inside the stream stored at key.
+func (r *Redis) XLen(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("XLEN", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// XDel removes the entries with ids from the stream stored at key.
+// Integer reply: the number of entries actually deleted.
+func (r *Redis) XDel(key string, ids ...string) (int64, error) {
+	args := packArgs("XDEL", key, ids)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// XTrim trims the stream stored at key.
+// Integer reply: the number of entries deleted from the stream.
+func (r *Redis) XTrim(key string, args *XTrimArgs) (int64, error) {
+	if args == nil {
+		return 0, errors.New("xtrim: no trimming strategy")
+	}
+	cmds := packArgs("XTRIM", key, args.args())
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// XRange returns the entries of the stream stored at key with IDs between start and end.
+// The special IDs - and + mean the minimum and the maximum ID, a ( prefix excludes the ID.
+// count limits the number of entries returned when it is positive.
+func (r *Redis) XRange(key, start, end string, count int) ([]*StreamEntry, error) {
+	args := packArgs("XRANGE", key, start, end)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return streamEntriesValue(rp)
+}
+
+// XRevRange is XRange in reverse order, starting from end down to start.
+func (r *Redis) XRevRange(key, end, start string, count int) ([]*StreamEntry, error) {
+	args := packArgs("XREVRANGE", key, end, start)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return streamEntriesValue(rp)
+}
+
+// XReadArgs is the arguments of XREAD.
+// IDs[i] is the last ID already seen in Streams[i], $ means the entries added from now on.
+// With Block the command waits up to Timeout for new entries, a zero Timeout waits forever.
+type XReadArgs struct {
+	Streams []string
+	IDs     []string
+	Count   int
+	Block   bool
+	Timeout time.Duration
+}
+
+func (a *XReadArgs) args() ([]interface{}, error) {
+	if len(a.Streams) == 0 || len(a.Streams) != len(a.IDs) {
+		return nil, errors.New("xread: streams and ids mismatch")
+	}
+	var args []interface{}
+	if a.Count > 0 {
+		args = append(args, "COUNT", a.Count)
+	}
+	if a.Block {
+		args = append(args, "BLOCK", int64(a.Timeout/time.Millisecond))
+	}
+	args = append(args, "STREAMS")
+	return packArgs(args, a.Streams, a.IDs), nil
+}
+
+// XRead reads entries with an ID greater than the given ones from one or more streams.
+// It returns nil when Block is set and the timeout expired.
+// Connections of the pool have no read deadline, so a blocking XREAD waits for the whole Timeout.
+func (r *Redis) XRead(args *XReadArgs) ([]*Stream, error) {
+	cmds, err := args.args()
+	if err != nil {
+		return nil, err
+	}
+	rp, err := r.ExecuteCommand(packArgs("XREAD", cmds)...)
+	if err != nil {
+		return nil, err
+	}
+	return streamsValue(rp)
+}
+
+func streamEntryValue(rp *Reply) (*StreamEntry, error) {
+	if rp.Type != MultiReply || len(rp.Multi) != 2 {
+		return nil, errors.New("stream entry protocol error")
+	}
+	id, err := rp.Multi[0].StringValue()
+	if err != nil {
+		return nil, err
+	}
+	entry := &StreamEntry{ID: id}
+	// A deleted entry which is still pending has no fields.
+	if rp.Multi[1].Type == MultiReply && rp.Multi[1].Multi != nil {
+		fields, err := rp.Multi[1].HashValue()
+		if err != nil {
+			return nil, err
+		}
+		entry.Fields = fields
+	}
+	return entry, nil
+}
+
+func streamEntriesValue(rp *Reply) ([]*StreamEntry, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*StreamEntry, 0, len(multi))
+	for _, subrp := range multi {
+		entry, err := streamEntryValue(subrp)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func streamsValue(rp *Reply) ([]*Stream, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if multi == nil {
+		return nil, nil
+	}
+	streams := make([]*Stream, 0, len(multi))
+	for _, subrp := range multi {
+		if subrp.Type != MultiReply || len(subrp.Multi) != 2 {
+			return nil, errors.New("xread protocol error")
+		}
+		key, err := subrp.Multi[0].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		entries, err := streamEntriesValue(subrp.Multi[1])
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, &Stream{key, entries})
+	}
+	return streams, nil
+}
+
+// XGroupCreate creates the consumer group for the stream stored at key,
+// id is the last delivered ID of the group, $ means only new entries.
+// With mkStream the stream is created if it does not exist.
+func (r *Redis) XGroupCreate(key, group, id string, mkStream bool) error {
+	args := packArgs("XGROUP", "CREATE", key, group, id)
+	if mkStream {
+		args = append(args, "MKSTREAM")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// XGroupCreateConsumer creates a consumer in the consumer group.
+// True if the consumer was created, false if it already existed.
+func (r *Redis) XGroupCreateConsumer(key, group, consumer string) (bool, error) {
+	rp, err := r.ExecuteCommand("XGROUP", "CREATECONSUMER", key, group, consumer)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// XGroupDelConsumer deletes a consumer from the consumer group,
+// its pending entries are deleted too.
+// Integer reply: the number of pending entries the consumer had.
+func (r *Redis) XGroupDelConsumer(key, group, consumer string) (int64, error) {
+	rp, err := r.ExecuteCommand("XGROUP", "DELCONSUMER", key, group, consumer)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// XGroupSetID sets the last delivered ID of the consumer group.
+func (r *Redis) XGroupSetID(key, group, id string) error {
+	rp, err := r.ExecuteCommand("XGROUP", "SETID", key, group, id)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// XGroupDestroy destroys the consumer group, even if there are active consumers and pending entries.
+// True if the group was destroyed.
+func (r *Redis) XGroupDestroy(key, group string) (bool, error) {
+	rp, err := r.ExecuteCommand("XGROUP", "DESTROY", key, group)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// XReadGroupArgs is the arguments of XREADGROUP.
+// IDs[i] is > for entries never delivered to any consumer of Group,
+// or an ID to read the pending entries of Consumer after that ID.
+// NoAck does not add the delivered entries to the pending entries list.
+type XReadGroupArgs struct {
+	Group    string
+	Consumer string
+	Streams  []string
+	IDs      []string
+	Count    int
+	Block    bool
+	Timeout  time.Duration
+	NoAck    bool
+}
+
+// XReadGroup is XRead for a consumer of a consumer group.
+// It returns nil when Block is set and the timeout expired.
+func (r *Redis) XReadGroup(args *XReadGroupArgs) ([]*Stream, error) {
+	read := &XReadArgs{
+		Streams: args.Streams,
+		IDs:     args.IDs,
+		Count:   args.Count,
+		Block:   args.Block,
+		Timeout: args.Timeout,
+	}
+	cmds, err := read.args()
+	if err != nil {
+		return nil, err
+	}
+	group := packArgs("XREADGROUP", "GROUP", args.Group, args.Consumer)
+	if args.NoAck {
+		group = append(group, "NOACK")
+	}
+	rp, err := r.ExecuteCommand(packArgs(group, cmds)...)
+	if err != nil {
+		return nil, err
+	}
+	return streamsValue(rp)
+}
+
+// XAck removes the entries with ids from the pending entries list of the consumer group.
+// Integer reply: the number of entries acknowledged.
+func (r *Redis) XAck(key, group string, ids ...string) (int64, error) {
+	args := packArgs("XACK", key, group, ids)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// XPendingSummary is the summary form of XPENDING.
+// Consumers maps every consumer with pending entries to its number of pending entries.
+type XPendingSummary struct {
+	Count     int64
+	Lowest    string
+	Highest   string
+	Consumers map[string]int64
+}
+
+// XPending returns the summary of the pending entries of the consumer group.
+func (r *Redis) XPending(key, group string) (*XPendingSummary, error) {
+	rp, err := r.ExecuteCommand("XPENDING", key, group)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if len(multi) != 4 {
+		return nil, errors.New("xpending protocol error")
+	}
+	count, err := multi[0].IntegerValue()
+	if err != nil {
+		return nil, err
+	}
+	summary := &XPendingSummary{Count: count, Consumers: make(map[string]int64)}
+	if summary.Lowest, err = multi[1].StringValue(); err != nil {
+		return nil, err
+	}
+	if summary.Highest, err = multi[2].StringValue(); err != nil {
+		return nil, err
+	}
+	for _, subrp := range multi[3].Multi {
+		fields, err := subrp.ListValue()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) != 2 {
+			return nil, errors.New("xpending protocol error")
+		}
+		n, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		summary.Consumers[fields[0]] = n
+	}
+	return summary, nil
+}
+
+// XPendingArgs is the arguments of the extended form of XPENDING.
+// Consumer and Idle are optional filters.
+type XPendingArgs struct {
+	Key      string
+	Group    string
+	Start    string
+	End      string
+	Count    int
+	Consumer string
+	Idle     time.Duration
+}
+
+// XPendingEntry is a pending entry, Idle is the time since it was last delivered.
+type XPendingEntry struct {
+	ID            string
+	Consumer      string
+	Idle          time.Duration
+	DeliveryCount int64
+}
+
+// XPendingExt returns the pending entries of the consumer group between Start and End.
+func (r *Redis) XPendingExt(args *XPendingArgs) ([]*XPendingEntry, error) {
+	cmds := packArgs("XPENDING", args.Key, args.Group)
+	if args.Idle > 0 {
+		cmds = append(cmds, "IDLE", int64(args.Idle/time.Millisecond))
+	}
+	cmds = append(cmds, args.Start, args.End, args.Count)
+	if args.Consumer != "" {
+		cmds = append(cmds, args.Consumer)
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*XPendingEntry, 0, len(multi))
+	for _, subrp := range multi {
+		if subrp.Type != MultiReply || len(subrp.Multi) != 4 {
+			return nil, errors.New("xpending protocol error")
+		}
+		id, err := subrp.Multi[0].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		consumer, err := subrp.Multi[1].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		idle, err := subrp.Multi[2].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		count, err := subrp.Multi[3].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &XPendingEntry{id, consumer, time.Duration(idle) * time.Millisecond, count})
+	}
+	return entries, nil
+}
+
+// XClaimArgs is the arguments of XCLAIM.
+// Only the entries idle for at least MinIdle are claimed.
+// Idle sets their idle time instead of resetting it, RetryCount sets their delivery count,
+// and Force creates pending entries for IDs which are not pending yet.
+type XClaimArgs struct {
+	Key        string
+	Group      string
+	Consumer   string
+	MinIdle    time.Duration
+	IDs        []string
+	Idle       time.Duration
+	RetryCount int64
+	Force      bool
+}
+
+func (a *XClaimArgs) args() []interface{} {
+	args := packArgs("XCLAIM", a.Key, a.Group, a.Consumer, int64(a.MinIdle/time.Millisecond), a.IDs)
+	if a.Idle > 0 {
+		args = append(args, "IDLE", int64(a.Idle/time.Millisecond))
+	}
+	if a.RetryCount > 0 {
+		args = append(args, "RETRYCOUNT", a.RetryCount)
+	}
+	if a.Force {
+		args = append(args, "FORCE")
+	}
+	return args
+}
+
+// XClaim changes the owner of pending entries to Consumer and returns the claimed entries.
+func (r *Redis) XClaim(args *XClaimArgs) ([]*StreamEntry, error) {
+	rp, err := r.ExecuteCommand(args.args()...)
+	if err != nil {
+		return nil, err
+	}
+	return streamEntriesValue(rp)
+}
+
+// XClaimJustID is XClaim returning only the IDs of the claimed entries,
+// the delivery count of the entries is not incremented.
+func (r *Redis) XClaimJustID(args *XClaimArgs) ([]string, error) {
+	rp, err := r.ExecuteCommand(append(args.args(), "JUSTID")...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// XAutoClaim claims at most count pending entries idle for at least minIdle,
+// scanning the pending entries list of the group from start.
+// It returns the start of the next call, 0-0 once the whole list was scanned, and the claimed entries.
+// Available since Redis 6.2.
+func (r *Redis) XAutoClaim(key, group, consumer string, minIdle time.Duration, start string, count int) (string, []*StreamEntry, error) {
+	args := packArgs("XAUTOCLAIM", key, group, consumer, int64(minIdle/time.Millisecond), start)
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return "", nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(multi) < 2 {
+		return "", nil, errors.New("xautoclaim protocol error")
+	}
+	next, err := multi[0].StringValue()
+	if err != nil {
+		return "", nil, err
+	}
+	var entries []*StreamEntry
+	for _, subrp := range multi[1].Multi {
+		// Redis 6.2 replies a nil entry for the deleted ones.
+		if subrp.Type != MultiReply || subrp.Multi == nil {
+			continue
+		}
+		entry, err := streamEntryValue(subrp)
+		if err != nil {
+			return "", nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return next, entries, nil
+}
+
+// XInfoStreamResult is the reply of XINFO STREAM.
+// The fields only known by newer servers are left zero by older ones.
+type XInfoStreamResult struct {
+	Length               int64
+	RadixTreeKeys        int64
+	RadixTreeNodes       int64
+	Groups               int64
+	LastGeneratedID      string
+	MaxDeletedEntryID    string
+	EntriesAdded         int64
+	RecordedFirstEntryID string
+	FirstEntry           *StreamEntry
+	LastEntry            *StreamEntry
+}
+
+// XInfoStream returns general information about the stream stored at key.
+func (r *Redis) XInfoStream(key string) (*XInfoStreamResult, error) {
+	rp, err := r.ExecuteCommand("XINFO", "STREAM", key)
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == ErrorReply {
+		return nil, errors.New(rp.Error)
+	}
+	if rp.Type != MultiReply {
+		return nil, errors.New("xinfo stream protocol error")
+	}
+	info := &XInfoStreamResult{}
+	info.Length, _ = rp.field("length").IntegerValue()
+	info.RadixTreeKeys, _ = rp.field("radix-tree-keys").IntegerValue()
+	info.RadixTreeNodes, _ = rp.field("radix-tree-nodes").IntegerValue()
+	info.Groups, _ = rp.field("groups").IntegerValue()
+	info.LastGeneratedID, _ = rp.field("last-generated-id").StringValue()
+	info.MaxDeletedEntryID, _ = rp.field("max-deleted-entry-id").StringValue()
+	info.EntriesAdded, _ = rp.field("entries-added").IntegerValue()
+	info.RecordedFirstEntryID, _ = rp.field("recorded-first-entry-id").StringValue()
+	if entry := rp.field("first-entry"); entry.Type == MultiReply && entry.Multi != nil {
+		if info.FirstEntry, err = streamEntryValue(entry); err != nil {
+			return nil, err
+		}
+	}
+	if entry := rp.field("last-entry"); entry.Type == MultiReply && entry.Multi != nil {
+		if info.LastEntry, err = streamEntryValue(entry); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// XInfoGroup is a consumer group as described by XINFO GROUPS.
+// EntriesRead and Lag are -1 when unknown.
+type XInfoGroup struct {
+	Name            string
+	Consumers       int64
+	Pending         int64
+	LastDeliveredID string
+	EntriesRead     int64
+	Lag             int64
+}
+
+// XInfoGroups returns the consumer groups of the stream stored at key.
+func (r *Redis) XInfoGroups(key string) ([]*XInfoGroup, error) {
+	rp, err := r.ExecuteCommand("XINFO", "GROUPS", key)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*XInfoGroup, 0, len(multi))
+	for _, subrp := range multi {
+		group := &XInfoGroup{EntriesRead: -1, Lag: -1}
+		group.Name, _ = subrp.field("name").StringValue()
+		group.Consumers, _ = subrp.field("consumers").IntegerValue()
+		group.Pending, _ = subrp.field("pending").IntegerValue()
+		group.LastDeliveredID, _ = subrp.field("last-delivered-id").StringValue()
+		if n, err := subrp.field("entries-read").IntegerValue(); err == nil {
+			group.EntriesRead = n
+		}
+		if n, err := subrp.field("lag").IntegerValue(); err == nil {
+			group.Lag = n
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// XInfoConsumer is a consumer as described by XINFO CONSUMERS.
+// Idle is the time since its last attempted interaction,
+// Inactive the time since its last successful one, -1 when unknown.
+type XInfoConsumer struct {
+	Name     string
+	Pending  int64
+	Idle     time.Duration
+	Inactive time.Duration
+}
+
+// XInfoConsumers returns the consumers of the consumer group.
+func (r *Redis) XInfoConsumers(key, group string) ([]*XInfoConsumer, error) {
+	rp, err := r.ExecuteCommand("XINFO", "CONSUMERS", key, group)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	consumers := make([]*XInfoConsumer, 0, len(multi))
+	for _, subrp := range multi {
+		consumer := &XInfoConsumer{Inactive: -1}
+		consumer.Name, _ = subrp.field("name").StringValue()
+		consumer.Pending, _ = subrp.field("pending").IntegerValue()
+		if n, err := subrp.field("idle").IntegerValue(); err == nil {
+			consumer.Idle = time.Duration(n) * time.Millisecond
+		}
+		if n, err := subrp.field("inactive").IntegerValue(); err == nil && n >= 0 {
+			consumer.Inactive = time.Duration(n) * time.Millisecond
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
@@ -0,0 +1,249 @@
+package goredis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestXAdd(t *testing.T) {
+	r.Del("stream")
+	id, err := r.XAdd("stream", map[string]string{"field": "value"}, nil)
+	if err != nil {
+		t.Error(err)
+	} else if id == "" {
+		t.Fail()
+	}
+	if id, err := r.XAdd("stream", map[string]string{"field": "value"}, &XAddArgs{ID: "9999999999999-1"}); err != nil {
+		t.Error(err)
+	} else if id != "9999999999999-1" {
+		t.Fail()
+	}
+	if _, err := r.XAdd("stream", map[string]string{"field": "value"}, &XAddArgs{ID: "1-1"}); err == nil {
+		t.Fail()
+	}
+	if id, err := r.XAdd("nostream", map[string]string{"field": "value"}, &XAddArgs{NoMkStream: true}); err != nil {
+		t.Error(err)
+	} else if id != "" {
+		t.Fail()
+	}
+	if _, err := r.XAdd("stream", map[string]string{"field": "value"}, &XAddArgs{Trim: &XTrimArgs{MaxLen: 1}}); err != nil {
+		t.Error(err)
+	}
+	if n, err := r.XLen("stream"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
+func TestXRange(t *testing.T) {
+	r.Del("stream")
+	for _, id := range []string{"1-1", "2-1", "3-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	if entries, err := r.XRange("stream", "-", "+", 0); err != nil {
+		t.Error(err)
+	} else if len(entries) != 3 || entries[0].ID != "1-1" || entries[2].Fields["id"] != "3-1" {
+		t.Fail()
+	}
+	if entries, err := r.XRange("stream", "2", "+", 1); err != nil {
+		t.Error(err)
+	} else if len(entries) != 1 || entries[0].ID != "2-1" {
+		t.Fail()
+	}
+	if entries, err := r.XRevRange("stream", "+", "-", 2); err != nil {
+		t.Error(err)
+	} else if len(entries) != 2 || entries[0].ID != "3-1" || entries[1].ID != "2-1" {
+		t.Fail()
+	}
+}
+
+func TestXDel(t *testing.T) {
+	r.Del("stream")
+	r.XAdd("stream", map[string]string{"field": "value"}, &XAddArgs{ID: "1-1"})
+	if n, err := r.XDel("stream", "1-1", "2-1"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+}
+
+func TestXTrim(t *testing.T) {
+	r.Del("stream")
+	for _, id := range []string{"1-1", "2-1", "3-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	if n, err := r.XTrim("stream", &XTrimArgs{MinID: "2-1"}); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+	if n, err := r.XTrim("stream", &XTrimArgs{MaxLen: 0}); err != nil {
+		t.Error(err)
+	} else if n != 2 {
+		t.Fail()
+	}
+}
+
+func TestXRead(t *testing.T) {
+	r.Del("stream1", "stream2")
+	r.XAdd("stream1", map[string]string{"field": "value1"}, &XAddArgs{ID: "1-1"})
+	r.XAdd("stream2", map[string]string{"field": "value2"}, &XAddArgs{ID: "1-1"})
+	streams, err := r.XRead(&XReadArgs{Streams: []string{"stream1", "stream2"}, IDs: []string{"0", "0"}, Count: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 2 || streams[1].Key != "stream2" || streams[1].Entries[0].Fields["field"] != "value2" {
+		t.Fail()
+	}
+	if streams, err := r.XRead(&XReadArgs{Streams: []string{"stream1"}, IDs: []string{"$"}, Block: true, Timeout: 10 * time.Millisecond}); err != nil {
+		t.Error(err)
+	} else if streams != nil {
+		t.Fail()
+	}
+	if _, err := r.XRead(&XReadArgs{Streams: []string{"stream1"}}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestXReadBlock(t *testing.T) {
+	r.Del("stream")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		client, err := Dial(&DialConfig{network, address, db, password, timeout, maxidle})
+		if err != nil {
+			return
+		}
+		defer client.ClosePool()
+		client.XAdd("stream", map[string]string{"field": "value"}, nil)
+	}()
+	streams, err := r.XRead(&XReadArgs{Streams: []string{"stream"}, IDs: []string{"$"}, Block: true})
+	if err != nil {
+		t.Error(err)
+	} else if len(streams) != 1 || len(streams[0].Entries) != 1 {
+		t.Fail()
+	}
+}
+
+func TestXGroup(t *testing.T) {
+	r.Del("stream")
+	if err := r.XGroupCreate("stream", "group", "$", false); err == nil {
+		t.Fail()
+	}
+	if err := r.XGroupCreate("stream", "group", "$", true); err != nil {
+		t.Error(err)
+	}
+	if err := r.XGroupCreate("stream", "group", "$", true); err == nil {
+		t.Fail()
+	}
+	if b, err := r.XGroupCreateConsumer("stream", "group", "consumer"); err != nil {
+		t.Error(err)
+	} else if !b {
+		t.Fail()
+	}
+	if n, err := r.XGroupDelConsumer("stream", "group", "consumer"); err != nil {
+		t.Error(err)
+	} else if n != 0 {
+		t.Fail()
+	}
+	if err := r.XGroupSetID("stream", "group", "0"); err != nil {
+		t.Error(err)
+	}
+	if b, err := r.XGroupDestroy("stream", "group"); err != nil {
+		t.Error(err)
+	} else if !b {
+		t.Fail()
+	}
+}
+
+func TestXReadGroup(t *testing.T) {
+	r.Del("stream")
+	r.XGroupCreate("stream", "group", "0", true)
+	for _, id := range []string{"1-1", "2-1", "3-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	args := &XReadGroupArgs{Group: "group", Consumer: "consumer", Streams: []string{"stream"}, IDs: []string{">"}, Count: 2}
+	if streams, err := r.XReadGroup(args); err != nil {
+		t.Error(err)
+	} else if len(streams) != 1 || len(streams[0].Entries) != 2 || streams[0].Entries[1].ID != "2-1" {
+		t.Fail()
+	}
+	if n, err := r.XAck("stream", "group", "1-1"); err != nil {
+		t.Error(err)
+	} else if n != 1 {
+		t.Fail()
+	}
+	args.IDs = []string{"0"}
+	if streams, err := r.XReadGroup(args); err != nil {
+		t.Error(err)
+	} else if len(streams) != 1 || len(streams[0].Entries) != 1 || streams[0].Entries[0].ID != "2-1" {
+		t.Fail()
+	}
+}
+
+func TestXPending(t *testing.T) {
+	r.Del("stream")
+	r.XGroupCreate("stream", "group", "0", true)
+	for _, id := range []string{"1-1", "2-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	r.XReadGroup(&XReadGroupArgs{Group: "group", Consumer: "consumer", Streams: []string{"stream"}, IDs: []string{">"}})
+	if summary, err := r.XPending("stream", "group"); err != nil {
+		t.Error(err)
+	} else if summary.Count != 2 || summary.Lowest != "1-1" || summary.Highest != "2-1" || summary.Consumers["consumer"] != 2 {
+		t.Fail()
+	}
+	if entries, err := r.XPendingExt(&XPendingArgs{Key: "stream", Group: "group", Start: "-", End: "+", Count: 10, Consumer: "consumer"}); err != nil {
+		t.Error(err)
+	} else if len(entries) != 2 || entries[0].ID != "1-1" || entries[0].Consumer != "consumer" || entries[0].DeliveryCount != 1 {
+		t.Fail()
+	}
+}
+
+func TestXClaim(t *testing.T) {
+	r.Del("stream")
+	r.XGroupCreate("stream", "group", "0", true)
+	for _, id := range []string{"1-1", "2-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	r.XReadGroup(&XReadGroupArgs{Group: "group", Consumer: "dead", Streams: []string{"stream"}, IDs: []string{">"}})
+	if entries, err := r.XClaim(&XClaimArgs{Key: "stream", Group: "group", Consumer: "consumer", IDs: []string{"1-1"}}); err != nil {
+		t.Error(err)
+	} else if len(entries) != 1 || entries[0].Fields["id"] != "1-1" {
+		t.Fail()
+	}
+	if ids, err := r.XClaimJustID(&XClaimArgs{Key: "stream", Group: "group", Consumer: "consumer", IDs: []string{"2-1"}}); err != nil {
+		t.Error(err)
+	} else if len(ids) != 1 || ids[0] != "2-1" {
+		t.Fail()
+	}
+	if next, entries, err := r.XAutoClaim("stream", "group", "other", 0, "0-0", 1); err != nil {
+		t.Error(err)
+	} else if next != "2-1" || len(entries) != 1 || entries[0].ID != "1-1" {
+		t.Fail()
+	}
+}
+
+func TestXInfo(t *testing.T) {
+	r.Del("stream")
+	r.XGroupCreate("stream", "group", "0", true)
+	for _, id := range []string{"1-1", "2-1"} {
+		r.XAdd("stream", map[string]string{"id": id}, &XAddArgs{ID: id})
+	}
+	r.XReadGroup(&XReadGroupArgs{Group: "group", Consumer: "consumer", Streams: []string{"stream"}, IDs: []string{">"}, Count: 1})
+	if info, err := r.XInfoStream("stream"); err != nil {
+		t.Error(err)
+	} else if info.Length != 2 || info.Groups != 1 || info.LastGeneratedID != "2-1" || info.FirstEntry == nil || info.FirstEntry.ID != "1-1" {
+		t.Fail()
+	}
+	if groups, err := r.XInfoGroups("stream"); err != nil {
+		t.Error(err)
+	} else if len(groups) != 1 || groups[0].Name != "group" || groups[0].Pending != 1 || groups[0].LastDeliveredID != "1-1" {
+		t.Fail()
+	}
+	if consumers, err := r.XInfoConsumers("stream", "group"); err != nil {
+		t.Error(err)
+	} else if len(consumers) != 1 || consumers[0].Name != "consumer" || consumers[0].Pending != 1 {
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package goredis
 
 import (
+	"errors"
 	"strconv"
 )
 
@@ -24,6 +25,42 @@ func (r *Redis) BitCount(key string, start, end int) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// Units of BitRange.
+const (
+	BitUnitByte = "BYTE"
+	BitUnitBit  = "BIT"
+)
+
+// BitRange is a range of a string for BitCountRange and BitPos,
+// Start and End are byte indexes, or bit indexes if Unit is BIT.
+// The BIT unit is available since Redis 7.0.
+type BitRange struct {
+	Start int
+	End   int
+	Unit  string
+}
+
+func (rng *BitRange) args() []interface{} {
+	if rng == nil {
+		return nil
+	}
+	args := []interface{}{rng.Start, rng.End}
+	if rng.Unit != "" {
+		args = append(args, rng.Unit)
+	}
+	return args
+}
+
+// BitCountRange is BitCount over rng, the whole string if rng is nil.
+func (r *Redis) BitCountRange(key string, rng *BitRange) (int64, error) {
+	args := packArgs("BITCOUNT", key, rng.args())
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // BitOp performs a bitwise operation between multiple keys (containing string values)
 // and store the result in the destination key.
 // The BITOP command supports four bitwise operations:
@@ -43,6 +80,18 @@ func (r *Redis) BitOp(operation, destkey string, keys ...string) (int64, error)
 	return rp.IntegerValue()
 }
 
+// BitPos returns the position of the first bit set to 1 or 0 in a string, inside rng if not nil.
+// Integer reply: the position, -1 if no such bit is found.
+// Looking for a clear bit without rng, the string is considered padded with zeros on the right.
+func (r *Redis) BitPos(key string, bit int, rng *BitRange) (int64, error) {
+	args := packArgs("BITPOS", key, bit, rng.args())
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // Decr decrements the number stored at key by one.
 // If the key does not exist, it is set to 0 before performing the operation.
 // An error is returned if the key contains a value of the wrong type
@@ -78,6 +127,54 @@ func (r *Redis) Get(key string) ([]byte, error) {
 	return rp.BytesValue()
 }
 
+// GetDel gets the value of key and deletes the key.
+// Bulk reply: the value of key, or nil when key does not exist.
+// Available since Redis 6.2.
+func (r *Redis) GetDel(key string) ([]byte, error) {
+	rp, err := r.ExecuteCommand("GETDEL", key)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
+// GetExArgs is the expiration options of GETEX, at most one of them may be set.
+// EX and PX are timeouts in seconds and milliseconds, EXAT and PXAT Unix timestamps in seconds and milliseconds.
+// Persist removes the time to live of the key.
+type GetExArgs struct {
+	EX      int
+	PX      int
+	EXAT    int64
+	PXAT    int64
+	Persist bool
+}
+
+// GetEx gets the value of key and sets or removes its time to live. args may be nil.
+// Bulk reply: the value of key, or nil when key does not exist.
+// Available since Redis 6.2.
+func (r *Redis) GetEx(key string, args *GetExArgs) ([]byte, error) {
+	cmds := packArgs("GETEX", key)
+	if args != nil {
+		switch {
+		case args.EX > 0:
+			cmds = append(cmds, "EX", args.EX)
+		case args.PX > 0:
+			cmds = append(cmds, "PX", args.PX)
+		case args.EXAT > 0:
+			cmds = append(cmds, "EXAT", args.EXAT)
+		case args.PXAT > 0:
+			cmds = append(cmds, "PXAT", args.PXAT)
+		case args.Persist:
+			cmds = append(cmds, "PERSIST")
+		}
+	}
+	rp, err := r.ExecuteCommand(cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
 // GetBit returns the bit value at offset in the string value stored at key.
 // When offset is beyond the string length,
 // the string is assumed to be a contiguous space with 0 bits.
@@ -104,8 +201,18 @@ func (r *Redis) GetRange(key string, start, end int) (string, error) {
 	return rp.StringValue()
 }
 
+// GetRangeBytes is GetRange returning the substring as bytes.
+func (r *Redis) GetRangeBytes(key string, start, end int) ([]byte, error) {
+	rp, err := r.ExecuteCommand("GETRANGE", key, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
 // GetSet atomically sets key to value and returns the old value stored at key.
 // Returns an error when key exists but does not hold a string value.
+// GETSET is deprecated since Redis 6.2, on newer servers use SetGet(key, value, nil) instead.
 func (r *Redis) GetSet(key, value string) ([]byte, error) {
 	rp, err := r.ExecuteCommand("GETSET", key, value)
 	if err != nil {
@@ -160,6 +267,105 @@ func (r *Redis) IncrByFloat(key string, increment float64) (float64, error) {
 	return strconv.ParseFloat(s, 64)
 }
 
+// LCS returns the longest common subsequence of the strings stored at key1 and key2.
+// Available since Redis 7.0.
+func (r *Redis) LCS(key1, key2 string) (string, error) {
+	rp, err := r.ExecuteCommand("LCS", key1, key2)
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// LCSLen returns the length of the longest common subsequence of the strings stored at key1 and key2.
+func (r *Redis) LCSLen(key1, key2 string) (int64, error) {
+	rp, err := r.ExecuteCommand("LCS", key1, key2, "LEN")
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// LCSRange is an inclusive range of byte offsets in a string.
+type LCSRange struct {
+	Start int64
+	End   int64
+}
+
+// LCSMatchedPosition is a match of the longest common subsequence,
+// MatchLen is only set with withMatchLen.
+type LCSMatchedPosition struct {
+	Key1     LCSRange
+	Key2     LCSRange
+	MatchLen int64
+}
+
+// LCSMatch is the reply of LCS IDX, the matches come from the end of the strings.
+type LCSMatch struct {
+	Matches []LCSMatchedPosition
+	Len     int64
+}
+
+// LCSIdx returns the positions of the longest common subsequence of the strings stored at key1 and key2,
+// skipping the matches shorter than minMatchLen if not 0.
+func (r *Redis) LCSIdx(key1, key2 string, minMatchLen int, withMatchLen bool) (*LCSMatch, error) {
+	args := packArgs("LCS", key1, key2, "IDX")
+	if minMatchLen > 0 {
+		args = append(args, "MINMATCHLEN", minMatchLen)
+	}
+	if withMatchLen {
+		args = append(args, "WITHMATCHLEN")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == ErrorReply {
+		return nil, errors.New(rp.Error)
+	}
+	if rp.Type != MultiReply {
+		return nil, errors.New("lcs protocol error")
+	}
+	match := &LCSMatch{}
+	if match.Len, err = rp.field("len").IntegerValue(); err != nil {
+		return nil, err
+	}
+	for _, subrp := range rp.field("matches").Multi {
+		if subrp.Type != MultiReply || len(subrp.Multi) < 2 {
+			return nil, errors.New("lcs protocol error")
+		}
+		var position LCSMatchedPosition
+		if position.Key1, err = lcsRangeValue(subrp.Multi[0]); err != nil {
+			return nil, err
+		}
+		if position.Key2, err = lcsRangeValue(subrp.Multi[1]); err != nil {
+			return nil, err
+		}
+		if len(subrp.Multi) > 2 {
+			if position.MatchLen, err = subrp.Multi[2].IntegerValue(); err != nil {
+				return nil, err
+			}
+		}
+		match.Matches = append(match.Matches, position)
+	}
+	return match, nil
+}
+
+func lcsRangeValue(rp *Reply) (LCSRange, error) {
+	if rp.Type != MultiReply || len(rp.Multi) != 2 {
+		return LCSRange{}, errors.New("lcs protocol error")
+	}
+	start, err := rp.Multi[0].IntegerValue()
+	if err != nil {
+		return LCSRange{}, err
+	}
+	end, err := rp.Multi[1].IntegerValue()
+	if err != nil {
+		return LCSRange{}, err
+	}
+	return LCSRange{start, end}, nil
+}
+
 // MGet returns the values of all specified keys.
 // For every key that does not hold a string value or does not exist,
 // the special value nil is returned. Because of this, the operation never fails.
@@ -225,6 +431,74 @@ func (r *Redis) Set(key, value string, seconds, milliseconds int, mustExists, mu
 	return rp.OKValue()
 }
 
+// SetArgs is the options of SET.
+// At most one expiration option may be set:
+// EX and PX are timeouts in seconds and milliseconds, EXAT and PXAT Unix timestamps in seconds and milliseconds,
+// and KeepTTL keeps the time to live of the key.
+// NX only sets the key if it does not exist, XX only if it exists.
+type SetArgs struct {
+	EX      int
+	PX      int
+	EXAT    int64
+	PXAT    int64
+	KeepTTL bool
+	NX      bool
+	XX      bool
+}
+
+func (a *SetArgs) args() []interface{} {
+	var args []interface{}
+	if a == nil {
+		return args
+	}
+	switch {
+	case a.EX > 0:
+		args = append(args, "EX", a.EX)
+	case a.PX > 0:
+		args = append(args, "PX", a.PX)
+	case a.EXAT > 0:
+		args = append(args, "EXAT", a.EXAT)
+	case a.PXAT > 0:
+		args = append(args, "PXAT", a.PXAT)
+	case a.KeepTTL:
+		args = append(args, "KEEPTTL")
+	}
+	if a.NX {
+		args = append(args, "NX")
+	} else if a.XX {
+		args = append(args, "XX")
+	}
+	return args
+}
+
+// SetWithArgs is Set with options. args may be nil.
+// False if the key was not set because of NX or XX.
+func (r *Redis) SetWithArgs(key, value string, args *SetArgs) (bool, error) {
+	rp, err := r.ExecuteCommand(packArgs("SET", key, value, args.args())...)
+	if err != nil {
+		return false, err
+	}
+	if rp.Type == BulkReply && rp.Bulk == nil {
+		return false, nil
+	}
+	if err := rp.OKValue(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetGet is SetWithArgs with the GET option, replacing GetSet.
+// Bulk reply: the old value stored at key, or nil when key did not exist.
+// With NX or XX the old value is returned even if the key was not set, NX needs Redis 7.0.
+// Available since Redis 6.2.
+func (r *Redis) SetGet(key, value string, args *SetArgs) ([]byte, error) {
+	rp, err := r.ExecuteCommand(packArgs("SET", key, value, args.args(), "GET")...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
 // SimpleSet do SET key value, no other arguments.
 func (r *Redis) SimpleSet(key, value string) error {
 	return r.Set(key, value, 0, 0, false, false)
@@ -269,6 +543,15 @@ func (r *Redis) SetRange(key string, offset int, value string) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// SetRangeBytes is SetRange with a binary value.
+func (r *Redis) SetRangeBytes(key string, offset int, value []byte) (int64, error) {
+	rp, err := r.ExecuteCommand("SETRANGE", key, offset, value)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // StrLen returns the length of the string value stored at key.
 // An error is returned when key holds a non-string value.
 // Integer reply: the length of the string at key, or 0 when key does not exist.
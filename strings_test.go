@@ -42,6 +42,44 @@ func TestBitCount(t *testing.T) {
 	}
 }
 
+func TestBitCountRange(t *testing.T) {
+	r.Set("key", "foobar", 0, 0, false, false)
+	if n, err := r.BitCountRange("key", nil); err != nil {
+		t.Error(err)
+	} else if n != 26 {
+		t.Fail()
+	}
+	if n, err := r.BitCountRange("key", &BitRange{1, 1, BitUnitByte}); err != nil {
+		t.Error(err)
+	} else if n != 6 {
+		t.Fail()
+	}
+	if n, err := r.BitCountRange("key", &BitRange{5, 30, BitUnitBit}); err != nil {
+		t.Error(err)
+	} else if n != 17 {
+		t.Fail()
+	}
+}
+
+func TestBitPos(t *testing.T) {
+	r.Set("key", "\xff\xf0\x00", 0, 0, false, false)
+	if n, err := r.BitPos("key", 0, nil); err != nil {
+		t.Error(err)
+	} else if n != 12 {
+		t.Fail()
+	}
+	if n, err := r.BitPos("key", 1, &BitRange{2, -1, ""}); err != nil {
+		t.Error(err)
+	} else if n != -1 {
+		t.Fail()
+	}
+	if n, err := r.BitPos("key", 1, &BitRange{7, 15, BitUnitBit}); err != nil {
+		t.Error(err)
+	} else if n != 7 {
+		t.Fail()
+	}
+}
+
 func TestBitOp(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	if _, err := r.BitOp("NOT", "key2", "key"); err != nil {
@@ -110,6 +148,41 @@ func TestGetBit(t *testing.T) {
 	}
 }
 
+func TestGetDel(t *testing.T) {
+	r.Set("key", "value", 0, 0, false, false)
+	if value, err := r.GetDel("key"); err != nil {
+		t.Error(err)
+	} else if string(value) != "value" {
+		t.Fail()
+	}
+	if value, err := r.GetDel("key"); err != nil {
+		t.Error(err)
+	} else if value != nil {
+		t.Fail()
+	}
+}
+
+func TestGetEx(t *testing.T) {
+	r.Set("key", "value", 0, 0, false, false)
+	if value, err := r.GetEx("key", &GetExArgs{EX: 100}); err != nil {
+		t.Error(err)
+	} else if string(value) != "value" {
+		t.Fail()
+	}
+	if ttl, _ := r.TTL("key"); ttl <= 0 {
+		t.Fail()
+	}
+	r.GetEx("key", &GetExArgs{Persist: true})
+	if ttl, _ := r.TTL("key"); ttl != -1 {
+		t.Fail()
+	}
+	if value, err := r.GetEx("nokey", nil); err != nil {
+		t.Error(err)
+	} else if value != nil {
+		t.Fail()
+	}
+}
+
 func TestGetRange(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	s, err := r.GetRange("key", 0, -1)
@@ -125,6 +198,15 @@ func TestGetRange(t *testing.T) {
 	}
 }
 
+func TestGetRangeBytes(t *testing.T) {
+	r.Set("key", "value", 0, 0, false, false)
+	if b, err := r.GetRangeBytes("key", 1, 2); err != nil {
+		t.Error(err)
+	} else if string(b) != "al" {
+		t.Fail()
+	}
+}
+
 func TestGetSet(t *testing.T) {
 	r.Del("key")
 	old, err := r.GetSet("key", "value")
@@ -184,6 +266,28 @@ func TestIncrByFloat(t *testing.T) {
 	}
 }
 
+func TestLCS(t *testing.T) {
+	r.Set("key1", "ohmytext", 0, 0, false, false)
+	r.Set("key2", "mynewtext", 0, 0, false, false)
+	if lcs, err := r.LCS("key1", "key2"); err != nil {
+		t.Error(err)
+	} else if lcs != "mytext" {
+		t.Fail()
+	}
+	if n, err := r.LCSLen("key1", "key2"); err != nil {
+		t.Error(err)
+	} else if n != 6 {
+		t.Fail()
+	}
+	if match, err := r.LCSIdx("key1", "key2", 4, true); err != nil {
+		t.Error(err)
+	} else if match.Len != 6 || len(match.Matches) != 1 {
+		t.Fail()
+	} else if m := match.Matches[0]; m.Key1 != (LCSRange{4, 7}) || m.Key2 != (LCSRange{5, 8}) || m.MatchLen != 4 {
+		t.Fail()
+	}
+}
+
 func TestMGet(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	ret, err := r.MGet("key", "key1")
@@ -249,6 +353,46 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestSetWithArgs(t *testing.T) {
+	r.Del("key")
+	if ok, err := r.SetWithArgs("key", "value", &SetArgs{XX: true}); err != nil {
+		t.Error(err)
+	} else if ok {
+		t.Fail()
+	}
+	if ok, err := r.SetWithArgs("key", "value", &SetArgs{NX: true, PX: 100000}); err != nil {
+		t.Error(err)
+	} else if !ok {
+		t.Fail()
+	}
+	if ok, _ := r.SetWithArgs("key", "value2", &SetArgs{KeepTTL: true}); !ok {
+		t.Fail()
+	}
+	if ttl, _ := r.PTTL("key"); ttl <= 0 {
+		t.Fail()
+	}
+	if ok, _ := r.SetWithArgs("key", "value", nil); !ok {
+		t.Fail()
+	}
+	if ttl, _ := r.TTL("key"); ttl != -1 {
+		t.Fail()
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	r.Del("key")
+	if old, err := r.SetGet("key", "value", nil); err != nil {
+		t.Error(err)
+	} else if old != nil {
+		t.Fail()
+	}
+	if old, err := r.SetGet("key", "value2", &SetArgs{EX: 100}); err != nil {
+		t.Error(err)
+	} else if string(old) != "value" {
+		t.Fail()
+	}
+}
+
 func TestSetEmptyValue(t *testing.T) {
 	if err := r.Set("key", "", 0, 0, false, false); err != nil {
 		t.Error(err)
@@ -302,6 +446,18 @@ func TestSetRange(t *testing.T) {
 	}
 }
 
+func TestSetRangeBytes(t *testing.T) {
+	r.Set("key", "value", 0, 0, false, false)
+	if n, err := r.SetRangeBytes("key", 1, []byte{0, 0xff}); err != nil {
+		t.Error(err)
+	} else if n != 5 {
+		t.Fail()
+	}
+	if b, _ := r.GetRangeBytes("key", 1, 2); len(b) != 2 || b[0] != 0 || b[1] != 0xff {
+		t.Fail()
+	}
+}
+
 func TestStrlen(t *testing.T) {
 	r.Set("key", "value", 0, 0, false, false)
 	n, err := r.StrLen("key")
@@ -85,8 +85,12 @@ func (t *Transaction) Exec() ([]*Reply, error) {
 // Command send raw redis command to redis server
 // and redis will return QUEUED back
 func (t *Transaction) Command(args ...interface{}) error {
-	args2 := packArgs(args...)
-	if err := t.conn.SendCommand(args2...); err != nil {
+	return t.queue(packArgs(args...))
+}
+
+// queue sends args as they are, and checks that the command was queued.
+func (t *Transaction) queue(args []interface{}) error {
+	if err := t.conn.SendCommand(args...); err != nil {
 		return err
 	}
 	rp, err := t.conn.RecvReply()